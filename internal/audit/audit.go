@@ -0,0 +1,324 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+	"go.uber.org/zap"
+)
+
+const redacted = "[redacted]"
+
+// params that are never written to the audit log
+var secretParams = map[string]bool{
+	"auth_password": true,
+	"password":      true,
+	"secret":        true,
+}
+
+// Record is a single audited operation
+type Record struct {
+	Time       time.Time         `json:"time"`
+	Identity   string            `json:"identity"`
+	AuthMethod string            `json:"auth_method"`
+	SourceIP   string            `json:"source_ip"`
+	Operation  string            `json:"operation"`
+	Params     map[string]string `json:"params,omitempty"`
+	Result     string            `json:"result"`
+	Error      string            `json:"error,omitempty"`
+	LatencyMS  float64           `json:"latency_ms"`
+}
+
+// Filter selects records returned by Query. Zero fields match everything.
+type Filter struct {
+	Identity  string
+	Operation string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+// Log appends records as JSON lines to a file rotated by size
+type Log struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+
+	logger *zap.Logger
+}
+
+// New opens the audit file. A Log with an empty file path discards records.
+func New(c config.Audit, l *zap.Logger) (*Log, error) {
+	a := &Log{
+		path:       c.File,
+		maxSize:    int64(c.MaxSizeMB) * 1024 * 1024,
+		maxBackups: c.MaxBackups,
+		logger:     l,
+	}
+	if a.path == "" {
+		l.Info("audit log disabled")
+		return a, nil
+	}
+	if err := a.open(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Enabled reports whether records are persisted
+func (a *Log) Enabled() bool {
+	return a.path != ""
+}
+
+// Write redacts secret params and appends x to the log
+func (a *Log) Write(x Record) {
+	if a.path == "" {
+		return
+	}
+	for k := range x.Params {
+		if secretParams[strings.ToLower(k)] {
+			x.Params[k] = redacted
+		}
+	}
+	b, err := json.Marshal(&x)
+	if err != nil {
+		a.logger.Error("could not encode audit record", zap.Error(err))
+		return
+	}
+	b = append(b, '\n')
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.f == nil {
+		// a failed rotation left no open file
+		if err := a.open(); err != nil {
+			a.logger.Error("could not write audit record", zap.Error(err), zap.String("operation", x.Operation))
+			return
+		}
+	}
+	if a.maxSize > 0 && a.size+int64(len(b)) > a.maxSize {
+		if err := a.rotate(); err != nil {
+			a.logger.Error("could not rotate audit log", zap.Error(err))
+		}
+	}
+	if a.f == nil {
+		return
+	}
+	n, err := a.f.Write(b)
+	a.size += int64(n)
+	if err != nil {
+		a.logger.Error("could not write audit record", zap.Error(err), zap.String("operation", x.Operation))
+	}
+}
+
+// Query returns matching records from the current and rotated files, newest
+// first in the order they were written. Files are read backwards and only
+// until the limit is reached. The files are opened under the lock and read
+// after releasing it, so a long scan does not block Write and a rotation
+// during the scan does not move records between files.
+func (a *Log) Query(f Filter) ([]Record, error) {
+	r := []Record{}
+	if a.path == "" {
+		return r, nil
+	}
+	files, err := a.snapshot()
+	if err != nil {
+		return r, err
+	}
+	defer func() {
+		for _, x := range files {
+			x.f.Close()
+		}
+	}()
+	for _, x := range files {
+		limit := 0
+		if f.Limit > 0 {
+			limit = f.Limit - len(r)
+		}
+		z, err := readRecords(x.f, x.size, f, limit)
+		if err != nil {
+			return r, err
+		}
+		r = append(r, z...)
+		if f.Limit > 0 && len(r) >= f.Limit {
+			return r, nil
+		}
+	}
+	return r, nil
+}
+
+type snapshotFile struct {
+	f    *os.File
+	size int64
+}
+
+// snapshot opens the current and rotated files newest first, with their size
+// at the time of the call
+func (a *Log) snapshot() ([]snapshotFile, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	names := []string{a.path}
+	for i := 1; i <= a.maxBackups; i++ {
+		names = append(names, a.backup(i))
+	}
+	files := []snapshotFile{}
+	for _, name := range names {
+		fh, err := os.Open(name)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err == nil {
+			var st os.FileInfo
+			st, err = fh.Stat()
+			if err == nil {
+				files = append(files, snapshotFile{f: fh, size: st.Size()})
+				continue
+			}
+			fh.Close()
+		}
+		for _, x := range files {
+			x.f.Close()
+		}
+		return nil, err
+	}
+	return files, nil
+}
+
+// Close closes the audit file
+func (a *Log) Close() error {
+	if a.path == "" {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.f == nil {
+		return nil
+	}
+	return a.f.Close()
+}
+
+func (a *Log) open() error {
+	a.f = nil
+	f, err := os.OpenFile(a.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open audit log: %w", err)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	a.f = f
+	a.size = st.Size()
+	return nil
+}
+
+func (a *Log) backup(i int) string {
+	return fmt.Sprintf("%s.%d", a.path, i)
+}
+
+// rotate shifts file.N to file.N+1, dropping the oldest, and starts a new
+// file. The file is reopened even when shifting fails so writes never go to
+// a closed file.
+func (a *Log) rotate() error {
+	err := a.f.Close()
+	if err == nil {
+		err = a.shift()
+	}
+	if oerr := a.open(); oerr != nil {
+		return errors.Join(err, oerr)
+	}
+	return err
+}
+
+func (a *Log) shift() error {
+	if a.maxBackups > 0 {
+		os.Remove(a.backup(a.maxBackups))
+		for i := a.maxBackups - 1; i >= 1; i-- {
+			os.Rename(a.backup(i), a.backup(i+1))
+		}
+		return os.Rename(a.path, a.backup(1))
+	}
+	return os.Truncate(a.path, 0)
+}
+
+// readRecords returns up to limit records matching f from the first size
+// bytes of rd, last written first. A zero limit returns every match.
+func readRecords(rd io.ReaderAt, size int64, f Filter, limit int) ([]Record, error) {
+	r := []Record{}
+	err := readLinesReverse(rd, size, func(line []byte) bool {
+		x := Record{}
+		if err := json.Unmarshal(line, &x); err != nil || !f.match(x) {
+			return true
+		}
+		r = append(r, x)
+		return limit <= 0 || len(r) < limit
+	})
+	return r, err
+}
+
+const (
+	readChunkSize = 64 * 1024
+	maxRecordSize = 1024 * 1024
+)
+
+// readLinesReverse calls fn with each non empty line of the first size bytes
+// of rd, last line first, until fn returns false
+func readLinesReverse(rd io.ReaderAt, size int64, fn func([]byte) bool) error {
+	// partial line carried over from the start of the previous chunk
+	var tail []byte
+	for off := size; off > 0; {
+		n := min(off, readChunkSize)
+		off -= n
+		b := make([]byte, n, n+int64(len(tail)))
+		if _, err := rd.ReadAt(b, off); err != nil && err != io.EOF {
+			return err
+		}
+		b = append(b, tail...)
+		for {
+			i := bytes.LastIndexByte(b, '\n')
+			if i < 0 {
+				break
+			}
+			if line := b[i+1:]; len(line) > 0 && !fn(line) {
+				return nil
+			}
+			b = b[:i]
+		}
+		if len(b) > maxRecordSize {
+			return fmt.Errorf("audit record larger than %d bytes", maxRecordSize)
+		}
+		tail = b
+	}
+	if len(tail) > 0 {
+		fn(tail)
+	}
+	return nil
+}
+
+func (f Filter) match(x Record) bool {
+	if f.Identity != "" && f.Identity != x.Identity {
+		return false
+	}
+	if f.Operation != "" && f.Operation != x.Operation {
+		return false
+	}
+	if !f.Since.IsZero() && x.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && x.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+	"go.uber.org/zap"
+)
+
+// newTestLog returns a Log in a temp dir that rotates after maxSize bytes
+func newTestLog(t *testing.T, maxSize int64, maxBackups int) *Log {
+	t.Helper()
+	a, err := New(config.Audit{File: filepath.Join(t.TempDir(), "audit.log"), MaxBackups: maxBackups}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.maxSize = maxSize
+	t.Cleanup(func() { a.Close() })
+	return a
+}
+
+func record(i int, identity string) Record {
+	return Record{
+		Time:      time.Unix(1700000000+int64(i), 0).UTC(),
+		Identity:  identity,
+		Operation: fmt.Sprintf("op.%d", i),
+		Result:    "ok",
+	}
+}
+
+func operations(r []Record) []string {
+	x := []string{}
+	for _, v := range r {
+		x = append(x, v.Operation)
+	}
+	return x
+}
+
+func TestQuery(t *testing.T) {
+	a := newTestLog(t, 0, 0)
+	for i := range 6 {
+		identity := "alice"
+		if i%2 == 1 {
+			identity = "bob"
+		}
+		a.Write(record(i, identity))
+	}
+	tests := []struct {
+		name string
+		f    Filter
+		want []string
+	}{
+		{name: "all", f: Filter{}, want: []string{"op.5", "op.4", "op.3", "op.2", "op.1", "op.0"}},
+		{name: "limit", f: Filter{Limit: 2}, want: []string{"op.5", "op.4"}},
+		{name: "identity", f: Filter{Identity: "bob"}, want: []string{"op.5", "op.3", "op.1"}},
+		{name: "identity and limit", f: Filter{Identity: "alice", Limit: 2}, want: []string{"op.4", "op.2"}},
+		{name: "operation", f: Filter{Operation: "op.3"}, want: []string{"op.3"}},
+		{name: "since and until", f: Filter{Since: time.Unix(1700000001, 0), Until: time.Unix(1700000003, 0)}, want: []string{"op.3", "op.2", "op.1"}},
+		{name: "no match", f: Filter{Identity: "carol"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := a.Query(tt.f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := operations(r); !slices.Equal(got, tt.want) {
+				t.Fatalf("Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteRedactsSecrets(t *testing.T) {
+	a := newTestLog(t, 0, 0)
+	x := record(0, "alice")
+	x.Params = map[string]string{"username": "100", "Password": "hunter2", "secret": "s"}
+	a.Write(x)
+	r, err := a.Query(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r) != 1 || r[0].Params["Password"] != redacted || r[0].Params["secret"] != redacted || r[0].Params["username"] != "100" {
+		t.Fatalf("Query() = %+v", r)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	// each record is about 130 bytes, so every file holds two
+	a := newTestLog(t, 300, 2)
+	for i := range 7 {
+		a.Write(record(i, "alice"))
+	}
+	for name, want := range map[string][]string{
+		a.path:      {"op.6"},
+		a.backup(1): {"op.4", "op.5"},
+		a.backup(2): {"op.2", "op.3"},
+	} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st, _ := f.Stat()
+		r, err := readRecords(f, st.Size(), Filter{}, 0)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		slices.Reverse(r)
+		if got := operations(r); !slices.Equal(got, want) {
+			t.Fatalf("%s holds %v, want %v", filepath.Base(name), got, want)
+		}
+	}
+	if _, err := os.Stat(a.backup(3)); !os.IsNotExist(err) {
+		t.Fatalf("backup beyond max_backups exists: %v", err)
+	}
+	r, err := a.Query(Filter{Limit: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := operations(r), []string{"op.6", "op.5", "op.4", "op.3"}; !slices.Equal(got, want) {
+		t.Fatalf("Query() = %v, want %v", got, want)
+	}
+}
+
+func TestRotateFailureKeepsWriting(t *testing.T) {
+	a := newTestLog(t, 300, 1)
+	// a non empty directory at the backup path makes the rename fail
+	if err := os.MkdirAll(filepath.Join(a.backup(1), "x"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 4 {
+		a.Write(record(i, "alice"))
+	}
+	if err := os.RemoveAll(a.backup(1)); err != nil {
+		t.Fatal(err)
+	}
+	r, err := a.Query(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := operations(r), []string{"op.3", "op.2", "op.1", "op.0"}; !slices.Equal(got, want) {
+		t.Fatalf("Query() = %v, want %v", got, want)
+	}
+}
+
+func TestWriteReopensClosedFile(t *testing.T) {
+	a := newTestLog(t, 0, 0)
+	a.Write(record(0, "alice"))
+	// as left by a rotation that could not reopen the file
+	a.f.Close()
+	a.f = nil
+	a.Write(record(1, "alice"))
+	r, err := a.Query(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := operations(r), []string{"op.1", "op.0"}; !slices.Equal(got, want) {
+		t.Fatalf("Query() = %v, want %v", got, want)
+	}
+}
+
+func TestReadLinesReverse(t *testing.T) {
+	lines := []string{}
+	for i := range 5000 {
+		lines = append(lines, strings.Repeat("x", i%97)+fmt.Sprint(i))
+	}
+	b := []byte(strings.Join(lines, "\n") + "\n")
+	tests := []struct {
+		name string
+		size int64
+		want []string
+	}{
+		{name: "whole file", size: int64(len(b)), want: lines},
+		{name: "without trailing newline", size: int64(len(b)) - 1, want: lines},
+		{name: "empty", size: 0, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			err := readLinesReverse(bytes.NewReader(b), tt.size, func(l []byte) bool {
+				got = append(got, string(l))
+				return true
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			slices.Reverse(got)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("read %d lines, want %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
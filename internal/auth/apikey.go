@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+type apiKey struct {
+	sum [sha256.Size]byte
+	id  Identity
+}
+
+type apiKeyAuthenticator struct {
+	keys []apiKey
+}
+
+func newAPIKeyAuthenticator(c []config.APIKey) (*apiKeyAuthenticator, error) {
+	a := &apiKeyAuthenticator{}
+	for _, k := range c {
+		if k.Key == "" {
+			return nil, fmt.Errorf("api key [%s] is empty", k.Name)
+		}
+		a.keys = append(a.keys, apiKey{
+			sum: sha256.Sum256([]byte(k.Key)),
+			id:  Identity{Name: k.Name, Method: "apikey", Roles: k.Roles},
+		})
+	}
+	return a, nil
+}
+
+func (a *apiKeyAuthenticator) Authenticate(r *http.Request) (Identity, bool, error) {
+	key := r.Header.Get(apiKeyHeader)
+	if key == "" {
+		return Identity{}, false, nil
+	}
+	// compare digests so every configured key costs the same regardless of length
+	sum := sha256.Sum256([]byte(key))
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare(sum[:], k.sum[:]) == 1 {
+			return k.id, true, nil
+		}
+	}
+	return Identity{}, false, fmt.Errorf("%w: unknown api key", ErrInvalidCredentials)
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidCredentials is returned when a request carries credentials that do not verify
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+type contextKey struct{}
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+	Name   string
+	Method string
+	Roles  []string
+}
+
+// Anonymous is the identity assigned to every request when auth is disabled
+var Anonymous = Identity{Name: "anonymous", Method: "none"}
+
+// Authenticator verifies one credential scheme. ok is false when the request
+// carries no credentials for the scheme so the next authenticator can try.
+type Authenticator interface {
+	Authenticate(r *http.Request) (id Identity, ok bool, err error)
+}
+
+// Auth authenticates requests and checks route permissions
+type Auth struct {
+	enabled        bool
+	authenticators []Authenticator
+	challenge      string
+	roles          map[string][]permission
+	logger         *zap.Logger
+}
+
+func New(c config.Auth, l *zap.Logger) (*Auth, error) {
+	a := &Auth{
+		enabled: c.Enabled,
+		roles:   map[string][]permission{},
+		logger:  l,
+	}
+	if !a.enabled {
+		l.Warn("http auth disabled, all endpoints are unauthenticated")
+		return a, nil
+	}
+	for role, perms := range c.Roles {
+		for _, p := range perms {
+			x, err := parsePermission(p)
+			if err != nil {
+				return nil, fmt.Errorf("role [%s]: %w", role, err)
+			}
+			a.roles[role] = append(a.roles[role], x)
+		}
+	}
+	if len(c.Certs) > 0 {
+		x, err := newCertAuthenticator(c.Certs)
+		if err != nil {
+			return nil, err
+		}
+		a.Use(x)
+	}
+	if len(c.APIKeys) > 0 {
+		k, err := newAPIKeyAuthenticator(c.APIKeys)
+		if err != nil {
+			return nil, err
+		}
+		a.Use(k)
+	}
+	if len(c.Basic) > 0 {
+		b, err := newBasicAuthenticator(c.Basic)
+		if err != nil {
+			return nil, err
+		}
+		a.Use(b)
+		a.challenge = `Basic realm="kamailio-jsonrpc-client"`
+	}
+	if c.JWT.Algorithm != "" {
+		j, err := newJWTAuthenticator(c.JWT)
+		if err != nil {
+			return nil, err
+		}
+		a.Use(j)
+	}
+	if len(a.authenticators) == 0 {
+		return nil, errors.New("auth enabled but no client_certs, api_keys, basic or jwt configured")
+	}
+	return a, nil
+}
+
+// Use appends an authenticator to the chain
+func (a *Auth) Use(x Authenticator) {
+	a.authenticators = append(a.authenticators, x)
+}
+
+// Enabled reports whether requests are authenticated
+func (a *Auth) Enabled() bool {
+	return a.enabled
+}
+
+// Middleware authenticates every request and stores the identity in its context
+func (a *Auth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.enabled {
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), Anonymous)))
+			return
+		}
+		for _, x := range a.authenticators {
+			id, ok, err := x.Authenticate(r)
+			if err != nil {
+				a.logger.Info("authentication failed", zap.Error(err), zap.String("remote_addr", r.RemoteAddr), zap.String("path", r.URL.Path))
+				a.unauthorized(w)
+				return
+			}
+			if !ok {
+				continue
+			}
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), id)))
+			return
+		}
+		a.unauthorized(w)
+	})
+}
+
+// Require wraps a handler so it only runs when the caller holds perm
+func (a *Auth) Require(perm string, next http.HandlerFunc) http.HandlerFunc {
+	p, err := parsePermission(perm)
+	if err != nil {
+		panic(err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.enabled {
+			next(w, r)
+			return
+		}
+		id, ok := FromContext(r.Context())
+		if !ok || !a.allowed(id, p) {
+			a.logger.Info("permission denied", zap.String("identity", id.Name), zap.String("permission", perm), zap.String("path", r.URL.Path))
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode("forbidden")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// Allowed reports whether id holds perm through any of its roles
+func (a *Auth) Allowed(id Identity, perm string) bool {
+	if !a.enabled {
+		return true
+	}
+	p, err := parsePermission(perm)
+	if err != nil {
+		return false
+	}
+	return a.allowed(id, p)
+}
+
+func (a *Auth) allowed(id Identity, p permission) bool {
+	for _, role := range id.Roles {
+		for _, g := range a.roles[role] {
+			if g.grants(p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *Auth) unauthorized(w http.ResponseWriter) {
+	if a.challenge != "" {
+		w.Header().Set("WWW-Authenticate", a.challenge)
+	}
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode("unauthorized")
+}
+
+// WithIdentity returns a copy of ctx carrying id
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the identity stored by Middleware
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(contextKey{}).(Identity)
+	return id, ok
+}
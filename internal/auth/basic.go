@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type basicUser struct {
+	hash []byte
+	id   Identity
+}
+
+type basicAuthenticator struct {
+	users map[string]basicUser
+	// dummy is compared for unknown users so the response time does not
+	// reveal which usernames exist
+	dummy []byte
+}
+
+func newBasicAuthenticator(c []config.BasicUser) (*basicAuthenticator, error) {
+	a := &basicAuthenticator{users: map[string]basicUser{}}
+	cost := bcrypt.DefaultCost
+	for _, u := range c {
+		x, err := bcrypt.Cost([]byte(u.PasswordHash))
+		if err != nil {
+			return nil, fmt.Errorf("basic user [%s]: password_hash is not a bcrypt hash: %w", u.Username, err)
+		}
+		cost = x
+		a.users[u.Username] = basicUser{
+			hash: []byte(u.PasswordHash),
+			id:   Identity{Name: u.Username, Method: "basic", Roles: u.Roles},
+		}
+	}
+	dummy, err := bcrypt.GenerateFromPassword([]byte("dummy password"), cost)
+	if err != nil {
+		return nil, err
+	}
+	a.dummy = dummy
+	return a, nil
+}
+
+func (a *basicAuthenticator) Authenticate(r *http.Request) (Identity, bool, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return Identity{}, false, nil
+	}
+	u, ok := a.users[username]
+	if !ok {
+		bcrypt.CompareHashAndPassword(a.dummy, []byte(password))
+		return Identity{}, false, fmt.Errorf("%w: unknown user [%s]", ErrInvalidCredentials, username)
+	}
+	if bcrypt.CompareHashAndPassword(u.hash, []byte(password)) != nil {
+		return Identity{}, false, fmt.Errorf("%w: bad password for user [%s]", ErrInvalidCredentials, username)
+	}
+	return u.id, true, nil
+}
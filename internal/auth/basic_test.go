@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBasicAuthenticate(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := newBasicAuthenticator([]config.BasicUser{{Username: "ops", PasswordHash: string(hash), Roles: []string{"admin"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantOK   bool
+	}{
+		{name: "valid", username: "ops", password: "secret", wantOK: true},
+		{name: "bad password", username: "ops", password: "wrong"},
+		{name: "unknown user", username: "nobody", password: "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/v1/core", nil)
+			r.SetBasicAuth(tt.username, tt.password)
+			id, ok, err := a.Authenticate(r)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v, err = %v", ok, tt.wantOK, err)
+			}
+			if !tt.wantOK && !errors.Is(err, ErrInvalidCredentials) {
+				t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+			}
+			if tt.wantOK && id.Name != "ops" {
+				t.Fatalf("identity = %+v", id)
+			}
+		})
+	}
+}
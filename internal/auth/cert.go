@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+)
+
+type certAuthenticator struct {
+	subjects map[string]Identity
+}
+
+func newCertAuthenticator(c []config.ClientCert) (*certAuthenticator, error) {
+	a := &certAuthenticator{subjects: map[string]Identity{}}
+	for _, x := range c {
+		if x.Subject == "" {
+			return nil, fmt.Errorf("client cert [%s] has no subject", x.Name)
+		}
+		name := x.Name
+		if name == "" {
+			name = x.Subject
+		}
+		a.subjects[x.Subject] = Identity{Name: name, Method: "mtls", Roles: x.Roles}
+	}
+	return a, nil
+}
+
+// Authenticate maps a client certificate that was verified during the
+// handshake to an identity. Unverified and unmapped certificates are ignored
+// so the request can still authenticate with another scheme.
+func (a *certAuthenticator) Authenticate(r *http.Request) (Identity, bool, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return Identity{}, false, nil
+	}
+	leaf := r.TLS.VerifiedChains[0][0]
+	for _, s := range certSubjects(leaf) {
+		if id, ok := a.subjects[s]; ok {
+			return id, true, nil
+		}
+	}
+	return Identity{}, false, nil
+}
+
+func certSubjects(c *x509.Certificate) []string {
+	s := []string{}
+	if c.Subject.CommonName != "" {
+		s = append(s, c.Subject.CommonName)
+	}
+	s = append(s, c.DNSNames...)
+	s = append(s, c.EmailAddresses...)
+	for _, u := range c.URIs {
+		s = append(s, u.String())
+	}
+	return s
+}
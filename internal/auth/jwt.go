@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+)
+
+type jwtAuthenticator struct {
+	parser     *jwt.Parser
+	keyFunc    jwt.Keyfunc
+	rolesClaim string
+}
+
+func newJWTAuthenticator(c config.JWT) (*jwtAuthenticator, error) {
+	a := &jwtAuthenticator{rolesClaim: c.RolesClaim}
+	switch c.Algorithm {
+	case "HS256":
+		if c.Secret == "" {
+			return nil, errors.New("jwt algorithm HS256 requires secret")
+		}
+		secret := []byte(c.Secret)
+		a.keyFunc = func(*jwt.Token) (interface{}, error) {
+			return secret, nil
+		}
+	case "RS256":
+		if c.JWKSFile == "" {
+			return nil, errors.New("jwt algorithm RS256 requires jwks_file")
+		}
+		keys, err := loadJWKS(c.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.keyFunc = func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			if kid == "" && len(keys) == 1 {
+				for _, k := range keys {
+					return k, nil
+				}
+			}
+			k, ok := keys[kid]
+			if !ok {
+				return nil, fmt.Errorf("unknown key id [%s]", kid)
+			}
+			return k, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm [%s]", c.Algorithm)
+	}
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{c.Algorithm}),
+		jwt.WithExpirationRequired(),
+	}
+	if c.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(c.Issuer))
+	}
+	if c.Audience != "" {
+		opts = append(opts, jwt.WithAudience(c.Audience))
+	}
+	a.parser = jwt.NewParser(opts...)
+	return a, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (Identity, bool, error) {
+	h := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(h, "Bearer ")
+	if !ok {
+		return Identity{}, false, nil
+	}
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(strings.TrimSpace(token), claims, a.keyFunc); err != nil {
+		return Identity{}, false, fmt.Errorf("%w: %s", ErrInvalidCredentials, err)
+	}
+	sub, err := claims.GetSubject()
+	if err != nil || sub == "" {
+		return Identity{}, false, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+	return Identity{Name: sub, Method: "jwt", Roles: claimRoles(claims[a.rolesClaim])}, true, nil
+}
+
+// claimRoles accepts a roles claim as either a JSON array or a space separated string
+func claimRoles(v interface{}) []string {
+	switch x := v.(type) {
+	case string:
+		return strings.Fields(x)
+	case []interface{}:
+		r := []string{}
+		for _, i := range x {
+			if s, ok := i.(string); ok {
+				r = append(r, s)
+			}
+		}
+		return r
+	}
+	return nil
+}
+
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	type jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	z := jwks{}
+	if err := json.Unmarshal(b, &z); err != nil {
+		return nil, fmt.Errorf("could not parse jwks file [%s]: %w", path, err)
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range z.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key [%s]: bad modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key [%s]: bad exponent: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks file [%s] has no RSA signing keys", path)
+	}
+	return keys, nil
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func authenticateToken(t *testing.T, a *jwtAuthenticator, token string) (Identity, bool, error) {
+	t.Helper()
+	r := httptest.NewRequest("GET", "/v1/core", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return a.Authenticate(r)
+}
+
+func signHS256(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	a, err := newJWTAuthenticator(config.JWT{Algorithm: "HS256", Secret: testSecret, Issuer: "idp", RolesClaim: "roles"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour).Unix()
+	hs384, err := jwt.NewWithClaims(jwt.SigningMethodHS384, jwt.MapClaims{"sub": "ops", "iss": "idp", "exp": future}).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"sub": "ops", "iss": "idp", "exp": future}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		token     string
+		wantOK    bool
+		wantRoles []string
+	}{
+		{name: "valid", token: signHS256(t, jwt.MapClaims{"sub": "ops", "iss": "idp", "exp": future, "roles": []string{"admin", "viewer"}}), wantOK: true, wantRoles: []string{"admin", "viewer"}},
+		{name: "roles as string", token: signHS256(t, jwt.MapClaims{"sub": "ops", "iss": "idp", "exp": future, "roles": "admin viewer"}), wantOK: true, wantRoles: []string{"admin", "viewer"}},
+		{name: "expired", token: signHS256(t, jwt.MapClaims{"sub": "ops", "iss": "idp", "exp": time.Now().Add(-time.Minute).Unix()})},
+		{name: "no exp", token: signHS256(t, jwt.MapClaims{"sub": "ops", "iss": "idp"})},
+		{name: "wrong issuer", token: signHS256(t, jwt.MapClaims{"sub": "ops", "iss": "other", "exp": future})},
+		{name: "no subject", token: signHS256(t, jwt.MapClaims{"iss": "idp", "exp": future})},
+		{name: "wrong algorithm", token: hs384},
+		{name: "alg none", token: none},
+		{name: "garbage", token: "not.a.token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok, err := authenticateToken(t, a, tt.token)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v, err = %v", ok, tt.wantOK, err)
+			}
+			if !tt.wantOK {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id.Name != "ops" || id.Method != "jwt" || !slices.Equal(id.Roles, tt.wantRoles) {
+				t.Fatalf("identity = %+v", id)
+			}
+		})
+	}
+}
+
+func TestJWTRejectsHS256WhenRS256Configured(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := map[string]interface{}{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": "k1",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	b, _ := json.Marshal(jwks)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	a, err := newJWTAuthenticator(config.JWT{Algorithm: "RS256", JWKSFile: path, RolesClaim: "roles"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := jwt.MapClaims{"sub": "ops", "exp": time.Now().Add(time.Hour).Unix()}
+
+	rs := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	rs.Header["kid"] = "k1"
+	valid, err := rs.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := authenticateToken(t, a, valid); !ok || err != nil {
+		t.Fatalf("RS256 token: ok = %v, err = %v", ok, err)
+	}
+
+	// an HS256 token keyed with the public modulus must not pass as RS256
+	hs := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hs.Header["kid"] = "k1"
+	forged, err := hs.SignedString(key.N.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := authenticateToken(t, a, forged); ok || !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("HS256 token: ok = %v, err = %v", ok, err)
+	}
+
+	rs = jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	rs.Header["kid"] = "unknown"
+	unknown, err := rs.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := authenticateToken(t, a, unknown); ok {
+		t.Fatal("token with unknown kid was accepted")
+	}
+}
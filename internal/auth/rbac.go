@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// permission levels, a higher level implies every lower one
+const (
+	levelRead = iota + 1
+	levelWrite
+	levelAdmin
+	levelAny
+)
+
+var levels = map[string]int{
+	"read":  levelRead,
+	"write": levelWrite,
+	"admin": levelAdmin,
+	"*":     levelAny,
+}
+
+// permission is a route group and access level, e.g. htable:write.
+// The group may be * to match every group.
+type permission struct {
+	group string
+	level int
+}
+
+func parsePermission(s string) (permission, error) {
+	switch s {
+	case "*":
+		return permission{group: "*", level: levelAny}, nil
+	case "readonly":
+		return permission{group: "*", level: levelRead}, nil
+	}
+	group, action, ok := strings.Cut(s, ":")
+	if !ok || group == "" {
+		return permission{}, fmt.Errorf("invalid permission [%s], expected group:read|write|admin", s)
+	}
+	l, ok := levels[action]
+	if !ok {
+		return permission{}, fmt.Errorf("invalid permission [%s], unknown action [%s]", s, action)
+	}
+	return permission{group: group, level: l}, nil
+}
+
+// grants reports whether holding p satisfies the required permission r
+func (p permission) grants(r permission) bool {
+	if p.group != "*" && p.group != r.group {
+		return false
+	}
+	return p.level >= r.level
+}
@@ -0,0 +1,70 @@
+package auth
+
+import "testing"
+
+func TestParsePermission(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    permission
+		wantErr bool
+	}{
+		{in: "htable:read", want: permission{group: "htable", level: levelRead}},
+		{in: "htable:write", want: permission{group: "htable", level: levelWrite}},
+		{in: "dispatcher:admin", want: permission{group: "dispatcher", level: levelAdmin}},
+		{in: "cfg:*", want: permission{group: "cfg", level: levelAny}},
+		{in: "*:read", want: permission{group: "*", level: levelRead}},
+		{in: "*", want: permission{group: "*", level: levelAny}},
+		{in: "readonly", want: permission{group: "*", level: levelRead}},
+		{in: "htable", wantErr: true},
+		{in: ":read", wantErr: true},
+		{in: "htable:delete", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parsePermission(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePermission(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("parsePermission(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionGrants(t *testing.T) {
+	tests := []struct {
+		held     string
+		required string
+		want     bool
+	}{
+		{held: "htable:read", required: "htable:read", want: true},
+		{held: "htable:write", required: "htable:read", want: true},
+		{held: "htable:admin", required: "htable:write", want: true},
+		{held: "htable:read", required: "htable:write", want: false},
+		{held: "htable:write", required: "htable:admin", want: false},
+		{held: "htable:admin", required: "dispatcher:read", want: false},
+		{held: "htable:*", required: "htable:admin", want: true},
+		{held: "*:read", required: "dispatcher:read", want: true},
+		{held: "*:read", required: "dispatcher:write", want: false},
+		{held: "readonly", required: "cfg:read", want: true},
+		{held: "readonly", required: "cfg:write", want: false},
+		{held: "*", required: "rpc:admin", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.held+" "+tt.required, func(t *testing.T) {
+			p, err := parsePermission(tt.held)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := parsePermission(tt.required)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := p.grants(r); got != tt.want {
+				t.Fatalf("%s grants %s = %v, want %v", tt.held, tt.required, got, tt.want)
+			}
+		})
+	}
+}
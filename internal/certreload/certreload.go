@@ -0,0 +1,187 @@
+package certreload
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+	"go.uber.org/zap"
+)
+
+var minVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Reloader serves the configured certificate and client CA pool and reloads
+// them when the files change on disk
+type Reloader struct {
+	certFile   string
+	keyFile    string
+	caFile     string
+	minVersion uint16
+	clientAuth tls.ClientAuthType
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+	pool *x509.CertPool
+
+	watcher *fsnotify.Watcher
+	logger  *zap.Logger
+}
+
+// New loads the certificate files and starts watching them. It returns nil
+// when no certificate is configured.
+func New(c config.TLS, l *zap.Logger) (*Reloader, error) {
+	if c.CertFile == "" {
+		return nil, nil
+	}
+	if c.KeyFile == "" {
+		return nil, fmt.Errorf("tls cert_file set without key_file")
+	}
+	v, ok := minVersions[c.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported tls min_version [%s]", c.MinVersion)
+	}
+	r := &Reloader{
+		certFile:   c.CertFile,
+		keyFile:    c.KeyFile,
+		caFile:     c.ClientCAFile,
+		minVersion: v,
+		clientAuth: tls.NoClientCert,
+		logger:     l,
+	}
+	if r.caFile != "" {
+		switch c.ClientAuth {
+		case "", "require":
+			r.clientAuth = tls.RequireAndVerifyClientCert
+		case "optional":
+			r.clientAuth = tls.VerifyClientCertIfGiven
+		default:
+			return nil, fmt.Errorf("unsupported tls client_auth [%s]", c.ClientAuth)
+		}
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// watch directories rather than files so atomic renames and kubernetes
+	// secret symlink swaps are picked up
+	dirs := map[string]bool{}
+	for _, f := range []string{r.certFile, r.keyFile, r.caFile} {
+		if f == "" {
+			continue
+		}
+		dirs[filepath.Dir(f)] = true
+	}
+	for d := range dirs {
+		if err := w.Add(d); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+	r.watcher = w
+	go r.watch()
+	return r, nil
+}
+
+// TLSConfig returns a server config that always presents the latest loaded certificate
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: r.minVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   r.minVersion,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientCAs:    r.pool,
+				ClientAuth:   r.clientAuth,
+				NextProtos:   []string{"h2", "http/1.1"},
+			}, nil
+		},
+	}
+}
+
+// Close stops watching the certificate files
+func (r *Reloader) Close() error {
+	return r.watcher.Close()
+}
+
+func (r *Reloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("could not load tls key pair: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		b, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("could not read client ca file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return fmt.Errorf("no certificates found in client ca file [%s]", r.caFile)
+		}
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.pool = pool
+	r.mu.Unlock()
+	return nil
+}
+
+// watched reports whether a change to name can affect the loaded files.
+// Kubernetes secret volumes swap a ..data symlink instead of the files.
+func (r *Reloader) watched(name string) bool {
+	base := filepath.Base(name)
+	if strings.HasPrefix(base, "..") {
+		return true
+	}
+	for _, f := range []string{r.certFile, r.keyFile, r.caFile} {
+		if f != "" && filepath.Base(f) == base {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) watch() {
+	for {
+		select {
+		case e, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if !e.Has(fsnotify.Write) && !e.Has(fsnotify.Create) && !e.Has(fsnotify.Rename) && !e.Has(fsnotify.Remove) {
+				continue
+			}
+			if !r.watched(e.Name) {
+				continue
+			}
+			// a half written pair fails to load, the old certificate stays in
+			// use until the next event produces a valid pair
+			if err := r.reload(); err != nil {
+				r.logger.Warn("could not reload tls certificate", zap.Error(err), zap.String("event", e.String()))
+				continue
+			}
+			r.logger.Info("reloaded tls certificate", zap.String("event", e.String()))
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.logger.Error("tls certificate watcher error", zap.Error(err))
+		}
+	}
+}
@@ -1,11 +1,37 @@
 package config
 
-import viper "github.com/spf13/viper"
+import (
+	"fmt"
+	"time"
+
+	viper "github.com/spf13/viper"
+)
 
 const (
-	logLevel                = "LOG_LEVEL"
-	httpListenAddrEnvKey    = "HTTP_LISTEN_ADDR"
-	kamailioServerURLEnvKey = "KAMAILIO_SERVER_URL"
+	configFileEnvKey                    = "CONFIG_FILE"
+	logLevel                            = "LOG_LEVEL"
+	httpListenAddrEnvKey                = "HTTP_LISTEN_ADDR"
+	httpReadTimeoutEnvKey               = "HTTP_READ_TIMEOUT"
+	httpWriteTimeoutEnvKey              = "HTTP_WRITE_TIMEOUT"
+	httpIdleTimeoutEnvKey               = "HTTP_IDLE_TIMEOUT"
+	httpMaxBodyBytesEnvKey              = "HTTP_MAX_BODY_BYTES"
+	shutdownTimeoutEnvKey               = "SHUTDOWN_TIMEOUT"
+	readyTimeoutEnvKey                  = "READYZ_TIMEOUT"
+	readyCacheTTLEnvKey                 = "READYZ_CACHE_TTL"
+	kamailioServerURLEnvKey             = "KAMAILIO_SERVER_URL"
+	kamailioTimeoutEnvKey               = "KAMAILIO_HTTP_TIMEOUT"
+	kamailioKeepAliveEnvKey             = "KAMAILIO_HTTP_KEEPALIVE"
+	kamailioMaxIdleConnsEnvKey          = "KAMAILIO_HTTP_MAX_IDLE_CONNS"
+	kamailioIdleConnTimeoutEnvKey       = "KAMAILIO_HTTP_IDLE_CONN_TIMEOUT"
+	kamailioDialTimeoutEnvKey           = "KAMAILIO_HTTP_DIAL_TIMEOUT"
+	kamailioTCPKeepAliveEnvKey          = "KAMAILIO_HTTP_TCP_KEEPALIVE"
+	kamailioTLSHandshakeTimeoutEnvKey   = "KAMAILIO_HTTP_TLS_HANDSHAKE_TIMEOUT"
+	kamailioResponseHeaderTimeoutEnvKey = "KAMAILIO_HTTP_RESPONSE_HEADER_TIMEOUT"
+	ipBanHTableEnvKey                   = "KAMAILIO_HTABLE_IPBAN"
+	usrlocTableEnvKey                   = "KAMAILIO_USRLOC_TABLE"
+	authKey                             = "auth"
+	tlsKey                              = "tls"
+	auditKey                            = "audit"
 )
 
 // Config is exported
@@ -14,21 +40,114 @@ type Config struct {
 		Level string
 	}
 	HTTPListenAddr string
-	Kamailio       struct {
+	HTTP           struct {
+		ReadTimeout     time.Duration
+		WriteTimeout    time.Duration
+		IdleTimeout     time.Duration
+		MaxBodyBytes    int64
+		ShutdownTimeout time.Duration
+		ReadyTimeout    time.Duration
+		ReadyCacheTTL   time.Duration
+	}
+	Kamailio struct {
 		JSONRPC struct {
 			Server struct {
 				URL string
 			}
+			Client struct {
+				Timeout               time.Duration
+				KeepAlive             bool
+				MaxIdleConns          int
+				IdleConnTimeout       time.Duration
+				DialTimeout           time.Duration
+				TCPKeepAlive          time.Duration
+				TLSHandshakeTimeout   time.Duration
+				ResponseHeaderTimeout time.Duration
+			}
 		}
 		HTable struct {
 			UserCache string
+			IPBan     string
+		}
+		Usrloc struct {
+			Table string
 		}
 	}
+	Auth  Auth
+	TLS   TLS
+	Audit Audit
+}
+
+// Audit configures the audit log of mutating operations. Auditing is off when File is empty.
+type Audit struct {
+	File       string `mapstructure:"file"`
+	MaxSizeMB  int    `mapstructure:"max_size_mb"`
+	MaxBackups int    `mapstructure:"max_backups"`
+}
+
+// TLS configures the REST listener. The listener serves plain HTTP when CertFile is empty.
+type TLS struct {
+	CertFile     string `mapstructure:"cert_file"`
+	KeyFile      string `mapstructure:"key_file"`
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	// ClientAuth is require (default when ClientCAFile is set) or optional
+	ClientAuth string `mapstructure:"client_auth"`
+	MinVersion string `mapstructure:"min_version"`
+}
+
+// Auth holds the authentication and role settings for the REST server
+type Auth struct {
+	Enabled bool                `mapstructure:"enabled"`
+	APIKeys []APIKey            `mapstructure:"api_keys"`
+	Basic   []BasicUser         `mapstructure:"basic"`
+	JWT     JWT                 `mapstructure:"jwt"`
+	Certs   []ClientCert        `mapstructure:"client_certs"`
+	Roles   map[string][]string `mapstructure:"roles"`
+}
+
+// ClientCert maps a verified client certificate to an identity. Subject is
+// matched against the certificate CN and its DNS, email and URI SANs.
+type ClientCert struct {
+	Name    string   `mapstructure:"name"`
+	Subject string   `mapstructure:"subject"`
+	Roles   []string `mapstructure:"roles"`
+}
+
+// APIKey is a static key sent in the X-API-Key header
+type APIKey struct {
+	Name  string   `mapstructure:"name"`
+	Key   string   `mapstructure:"key"`
+	Roles []string `mapstructure:"roles"`
+}
+
+// BasicUser is an HTTP Basic user with a bcrypt password hash
+type BasicUser struct {
+	Username     string   `mapstructure:"username"`
+	PasswordHash string   `mapstructure:"password_hash"`
+	Roles        []string `mapstructure:"roles"`
+}
+
+// JWT configures bearer token validation. Algorithm is HS256 or RS256.
+type JWT struct {
+	Algorithm  string `mapstructure:"algorithm"`
+	Secret     string `mapstructure:"secret"`
+	JWKSFile   string `mapstructure:"jwks_file"`
+	Issuer     string `mapstructure:"issuer"`
+	Audience   string `mapstructure:"audience"`
+	RolesClaim string `mapstructure:"roles_claim"`
 }
 
-func LoadConfig() Config {
+func LoadConfig() (Config, error) {
 	c := Config{}
 
+	viper.BindEnv(configFileEnvKey)
+	if f := viper.GetString(configFileEnvKey); f != "" {
+		viper.SetConfigFile(f)
+		if err := viper.ReadInConfig(); err != nil {
+			return c, fmt.Errorf("could not read config file [%s]: %w", f, err)
+		}
+	}
+
 	viper.SetDefault(logLevel, "INFO")
 	viper.BindEnv(logLevel)
 	c.Log.Level = viper.GetString(logLevel)
@@ -37,9 +156,100 @@ func LoadConfig() Config {
 	viper.BindEnv(httpListenAddrEnvKey)
 	c.HTTPListenAddr = viper.GetString(httpListenAddrEnvKey)
 
+	viper.SetDefault(httpReadTimeoutEnvKey, "15s")
+	viper.BindEnv(httpReadTimeoutEnvKey)
+	c.HTTP.ReadTimeout = viper.GetDuration(httpReadTimeoutEnvKey)
+
+	// kamailio calls are allowed up to 60s so writes must outlast them
+	viper.SetDefault(httpWriteTimeoutEnvKey, "75s")
+	viper.BindEnv(httpWriteTimeoutEnvKey)
+	c.HTTP.WriteTimeout = viper.GetDuration(httpWriteTimeoutEnvKey)
+
+	viper.SetDefault(httpIdleTimeoutEnvKey, "120s")
+	viper.BindEnv(httpIdleTimeoutEnvKey)
+	c.HTTP.IdleTimeout = viper.GetDuration(httpIdleTimeoutEnvKey)
+
+	viper.SetDefault(httpMaxBodyBytesEnvKey, 1<<20)
+	viper.BindEnv(httpMaxBodyBytesEnvKey)
+	c.HTTP.MaxBodyBytes = viper.GetInt64(httpMaxBodyBytesEnvKey)
+
+	viper.SetDefault(shutdownTimeoutEnvKey, "30s")
+	viper.BindEnv(shutdownTimeoutEnvKey)
+	c.HTTP.ShutdownTimeout = viper.GetDuration(shutdownTimeoutEnvKey)
+
+	viper.SetDefault(readyTimeoutEnvKey, "2s")
+	viper.BindEnv(readyTimeoutEnvKey)
+	c.HTTP.ReadyTimeout = viper.GetDuration(readyTimeoutEnvKey)
+
+	viper.SetDefault(readyCacheTTLEnvKey, "5s")
+	viper.BindEnv(readyCacheTTLEnvKey)
+	c.HTTP.ReadyCacheTTL = viper.GetDuration(readyCacheTTLEnvKey)
+
 	viper.SetDefault(kamailioServerURLEnvKey, "http://localhost:8081/RPC")
 	viper.BindEnv(kamailioServerURLEnvKey)
 	c.Kamailio.JSONRPC.Server.URL = viper.GetString(kamailioServerURLEnvKey)
 
-	return c
+	viper.SetDefault(kamailioTimeoutEnvKey, "60s")
+	viper.BindEnv(kamailioTimeoutEnvKey)
+	c.Kamailio.JSONRPC.Client.Timeout = viper.GetDuration(kamailioTimeoutEnvKey)
+
+	viper.SetDefault(kamailioKeepAliveEnvKey, true)
+	viper.BindEnv(kamailioKeepAliveEnvKey)
+	c.Kamailio.JSONRPC.Client.KeepAlive = viper.GetBool(kamailioKeepAliveEnvKey)
+
+	viper.SetDefault(kamailioMaxIdleConnsEnvKey, 100)
+	viper.BindEnv(kamailioMaxIdleConnsEnvKey)
+	c.Kamailio.JSONRPC.Client.MaxIdleConns = viper.GetInt(kamailioMaxIdleConnsEnvKey)
+
+	viper.SetDefault(kamailioIdleConnTimeoutEnvKey, "90s")
+	viper.BindEnv(kamailioIdleConnTimeoutEnvKey)
+	c.Kamailio.JSONRPC.Client.IdleConnTimeout = viper.GetDuration(kamailioIdleConnTimeoutEnvKey)
+
+	viper.SetDefault(kamailioDialTimeoutEnvKey, "5s")
+	viper.BindEnv(kamailioDialTimeoutEnvKey)
+	c.Kamailio.JSONRPC.Client.DialTimeout = viper.GetDuration(kamailioDialTimeoutEnvKey)
+
+	viper.SetDefault(kamailioTCPKeepAliveEnvKey, "30s")
+	viper.BindEnv(kamailioTCPKeepAliveEnvKey)
+	c.Kamailio.JSONRPC.Client.TCPKeepAlive = viper.GetDuration(kamailioTCPKeepAliveEnvKey)
+
+	viper.SetDefault(kamailioTLSHandshakeTimeoutEnvKey, "10s")
+	viper.BindEnv(kamailioTLSHandshakeTimeoutEnvKey)
+	c.Kamailio.JSONRPC.Client.TLSHandshakeTimeout = viper.GetDuration(kamailioTLSHandshakeTimeoutEnvKey)
+
+	// 0 waits as long as the call ctx allows, tm.t_uac_wait can take the
+	// whole sip transaction timeout
+	viper.SetDefault(kamailioResponseHeaderTimeoutEnvKey, "0s")
+	viper.BindEnv(kamailioResponseHeaderTimeoutEnvKey)
+	c.Kamailio.JSONRPC.Client.ResponseHeaderTimeout = viper.GetDuration(kamailioResponseHeaderTimeoutEnvKey)
+
+	viper.SetDefault(ipBanHTableEnvKey, "ipban")
+	viper.BindEnv(ipBanHTableEnvKey)
+	c.Kamailio.HTable.IPBan = viper.GetString(ipBanHTableEnvKey)
+
+	viper.SetDefault(usrlocTableEnvKey, "location")
+	viper.BindEnv(usrlocTableEnvKey)
+	c.Kamailio.Usrloc.Table = viper.GetString(usrlocTableEnvKey)
+
+	if err := viper.UnmarshalKey(authKey, &c.Auth); err != nil {
+		return c, fmt.Errorf("could not parse auth config: %w", err)
+	}
+	if c.Auth.JWT.RolesClaim == "" {
+		c.Auth.JWT.RolesClaim = "roles"
+	}
+
+	if err := viper.UnmarshalKey(tlsKey, &c.TLS); err != nil {
+		return c, fmt.Errorf("could not parse tls config: %w", err)
+	}
+	if c.TLS.MinVersion == "" {
+		c.TLS.MinVersion = "1.2"
+	}
+
+	viper.SetDefault(auditKey+".max_size_mb", 100)
+	viper.SetDefault(auditKey+".max_backups", 5)
+	if err := viper.UnmarshalKey(auditKey, &c.Audit); err != nil {
+		return c, fmt.Errorf("could not parse audit config: %w", err)
+	}
+
+	return c, nil
 }
@@ -0,0 +1,130 @@
+package jsonrpcc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// batchSize caps the calls sent in one http request, larger batches are split
+const batchSize = 500
+
+// Call is one call of a batch. Params is a positional slice or an object of
+// named params and may be nil.
+type Call struct {
+	Method string      `json:"method"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+// CallResult is the outcome of the Call at the same index of a batch
+type CallResult struct {
+	Result json.RawMessage
+	Err    error
+}
+
+// Decode decodes the result into v, or returns the call error
+func (r CallResult) Decode(v interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	if len(r.Result) == 0 || v == nil {
+		return nil
+	}
+	return json.Unmarshal(r.Result, v)
+}
+
+// Batch sends calls as jsonrpc batch arrays and returns their results in the
+// order of calls. The error is set when a whole http request failed, each
+// CallResult carries the error of its own call.
+func (a *API) Batch(ctx context.Context, calls ...Call) ([]CallResult, error) {
+	a.logger.Debug("batch", zap.Int("calls", len(calls)))
+	x := make([]CallResult, 0, len(calls))
+	for i := 0; i < len(calls); i += batchSize {
+		z, err := a.batch(ctx, calls[i:min(i+batchSize, len(calls))])
+		if err != nil {
+			return x, err
+		}
+		x = append(x, z...)
+	}
+	return x, nil
+}
+
+func (a *API) batch(ctx context.Context, calls []Call) ([]CallResult, error) {
+	type request struct {
+		JSONRPC string      `json:"jsonrpc"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params,omitempty"`
+		ID      string      `json:"id"`
+	}
+	r := make([]request, 0, len(calls))
+	ids := make(map[string]int, len(calls))
+	for i, c := range calls {
+		id := uuid.New().String()
+		ids[id] = i
+		r = append(r, request{JSONRPC: "2.0", Method: c.Method, Params: c.Params, ID: id})
+	}
+	b, err := json.Marshal(&r)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(res.Body)
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		a.logger.Debug("unexpected status code", zap.String("method", "batch"), zap.Int("status code", res.StatusCode))
+		if err := jsonRPCError(body); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unexpected status code [%d]", res.StatusCode)
+	}
+	type response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		ID string `json:"id"`
+	}
+	z := []response{}
+	if err := json.Unmarshal(body, &z); err != nil {
+		return nil, err
+	}
+	x := make([]CallResult, len(calls))
+	seen := make([]bool, len(calls))
+	for _, v := range z {
+		i, ok := ids[v.ID]
+		if !ok {
+			a.logger.Debug("batch response with unknown id", zap.String("id", v.ID))
+			continue
+		}
+		seen[i] = true
+		if v.Error != nil && v.Error.Code != 0 {
+			x[i].Err = &RPCError{Code: v.Error.Code, Message: v.Error.Message}
+			continue
+		}
+		x[i].Result = v.Result
+	}
+	for i := range x {
+		if !seen[i] {
+			x[i].Err = fmt.Errorf("no response for method [%s]", calls[i].Method)
+		}
+	}
+	return x, nil
+}
@@ -0,0 +1,51 @@
+package jsonrpcc
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// CarrierRouteHost selects a host in the routing tree for activate and deactivate
+type CarrierRouteHost struct {
+	Carrier string `json:"carrier"`
+	Domain  string `json:"domain"`
+	Prefix  string `json:"prefix"`
+	Host    string `json:"host"`
+}
+
+// CarrierRouteDump returns the routing tree as printed by cr.dump_routes, one
+// line per route and host
+func (a *API) CarrierRouteDump(ctx context.Context) ([]string, error) {
+	a.logger.Debug("carrierroute dump routes")
+	x := rpcList[string]{}
+	if err := a.call(ctx, "cr.dump_routes", nil, &x); err != nil {
+		return []string{}, err
+	}
+	lines := []string{}
+	for _, v := range x {
+		for _, l := range strings.Split(v, "\n") {
+			if strings.TrimSpace(l) == "" {
+				continue
+			}
+			lines = append(lines, l)
+		}
+	}
+	return lines, nil
+}
+
+func (a *API) CarrierRouteReload(ctx context.Context) error {
+	a.logger.Debug("carrierroute reload routes")
+	return a.call(ctx, "cr.reload_routes", nil, nil)
+}
+
+func (a *API) CarrierRouteActivateHost(ctx context.Context, h CarrierRouteHost) error {
+	a.logger.Debug("carrierroute activate host", zap.String("carrier", h.Carrier), zap.String("domain", h.Domain), zap.String("prefix", h.Prefix), zap.String("host", h.Host))
+	return a.call(ctx, "cr.activate_host", []interface{}{h.Carrier, h.Domain, h.Prefix, h.Host}, nil)
+}
+
+func (a *API) CarrierRouteDeactivateHost(ctx context.Context, h CarrierRouteHost) error {
+	a.logger.Debug("carrierroute deactivate host", zap.String("carrier", h.Carrier), zap.String("domain", h.Domain), zap.String("prefix", h.Prefix), zap.String("host", h.Host))
+	return a.call(ctx, "cr.deactivate_host", []interface{}{h.Carrier, h.Domain, h.Prefix, h.Host}, nil)
+}
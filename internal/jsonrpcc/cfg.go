@@ -0,0 +1,188 @@
+package jsonrpcc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// CfgValue is a cfg framework variable. Type is int or str.
+type CfgValue struct {
+	Group string `json:"group"`
+	Var   string `json:"var"`
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// CfgVar is an entry of cfg.list
+type CfgVar struct {
+	Group string `json:"group"`
+	Var   string `json:"var"`
+}
+
+// CfgDiff is a delayed change that has not been committed yet
+type CfgDiff struct {
+	Group    string          `json:"group"`
+	Var      string          `json:"var"`
+	OldValue json.RawMessage `json:"old_value"`
+	NewValue json.RawMessage `json:"new_value"`
+}
+
+// CfgChange is one variable update. Value must be an int or a string.
+type CfgChange struct {
+	Group string      `json:"group"`
+	Var   string      `json:"var"`
+	Value interface{} `json:"value"`
+}
+
+func (a *API) CfgGet(ctx context.Context, group string, name string) (CfgValue, error) {
+	a.logger.Debug("cfg get", zap.String("group", group), zap.String("var", name))
+	var raw json.RawMessage
+	if err := a.call(ctx, "cfg.get", []interface{}{group, name}, &raw); err != nil {
+		return CfgValue{}, err
+	}
+	x := CfgValue{Group: group, Var: name}
+	var num json.Number
+	if err := json.Unmarshal(raw, &num); err == nil {
+		x.Value = num.String()
+		x.Type = "int"
+		return x, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return CfgValue{}, fmt.Errorf("unexpected cfg.get result [%s]", string(raw))
+	}
+	x.Value = s
+	x.Type = "str"
+	return x, nil
+}
+
+func (a *API) CfgSeti(ctx context.Context, group string, name string, value int) error {
+	a.logger.Debug("cfg seti", zap.String("group", group), zap.String("var", name), zap.Int("value", value))
+	return a.call(ctx, "cfg.seti", []interface{}{group, name, value}, nil)
+}
+
+func (a *API) CfgSets(ctx context.Context, group string, name string, value string) error {
+	a.logger.Debug("cfg sets", zap.String("group", group), zap.String("var", name), zap.String("value", value))
+	return a.call(ctx, "cfg.sets", []interface{}{group, name, value}, nil)
+}
+
+// CfgSetDelayedInt stages value for the next commit. The delayed calls share
+// cfgMu with CfgApply so a value staged by another caller is not discarded by
+// the rollback of a failed apply.
+func (a *API) CfgSetDelayedInt(ctx context.Context, group string, name string, value int) error {
+	a.cfgMu.Lock()
+	defer a.cfgMu.Unlock()
+	return a.cfgSetDelayedInt(ctx, group, name, value)
+}
+
+func (a *API) CfgSetDelayedString(ctx context.Context, group string, name string, value string) error {
+	a.cfgMu.Lock()
+	defer a.cfgMu.Unlock()
+	return a.cfgSetDelayedString(ctx, group, name, value)
+}
+
+func (a *API) CfgCommit(ctx context.Context) error {
+	a.cfgMu.Lock()
+	defer a.cfgMu.Unlock()
+	return a.cfgCommit(ctx)
+}
+
+func (a *API) CfgRollback(ctx context.Context) error {
+	a.cfgMu.Lock()
+	defer a.cfgMu.Unlock()
+	return a.cfgRollback(ctx)
+}
+
+func (a *API) cfgSetDelayedInt(ctx context.Context, group string, name string, value int) error {
+	a.logger.Debug("cfg set delayed int", zap.String("group", group), zap.String("var", name), zap.Int("value", value))
+	return a.call(ctx, "cfg.set_delayed_int", []interface{}{group, name, value}, nil)
+}
+
+func (a *API) cfgSetDelayedString(ctx context.Context, group string, name string, value string) error {
+	a.logger.Debug("cfg set delayed string", zap.String("group", group), zap.String("var", name), zap.String("value", value))
+	return a.call(ctx, "cfg.set_delayed_string", []interface{}{group, name, value}, nil)
+}
+
+func (a *API) cfgCommit(ctx context.Context) error {
+	a.logger.Debug("cfg commit")
+	return a.call(ctx, "cfg.commit", nil, nil)
+}
+
+func (a *API) cfgRollback(ctx context.Context) error {
+	a.logger.Debug("cfg rollback")
+	return a.call(ctx, "cfg.rollback", nil, nil)
+}
+
+// CfgList returns every variable known to the cfg framework
+func (a *API) CfgList(ctx context.Context) ([]CfgVar, error) {
+	a.logger.Debug("cfg list")
+	lines := []string{}
+	if err := a.call(ctx, "cfg.list", nil, &lines); err != nil {
+		return []CfgVar{}, err
+	}
+	x := []CfgVar{}
+	for _, l := range lines {
+		group, name, ok := strings.Cut(l, ":")
+		if !ok {
+			continue
+		}
+		x = append(x, CfgVar{Group: strings.TrimSpace(group), Var: strings.TrimSpace(name)})
+	}
+	return x, nil
+}
+
+// CfgDiff returns the delayed changes waiting for commit
+func (a *API) CfgDiff(ctx context.Context) ([]CfgDiff, error) {
+	a.logger.Debug("cfg diff")
+	type diff struct {
+		Group    string          `json:"group name"`
+		Var      string          `json:"variable name"`
+		OldValue json.RawMessage `json:"old value"`
+		NewValue json.RawMessage `json:"new value"`
+	}
+	z := []diff{}
+	if err := a.call(ctx, "cfg.diff", nil, &z); err != nil {
+		return []CfgDiff{}, err
+	}
+	x := []CfgDiff{}
+	for _, d := range z {
+		x = append(x, CfgDiff(d))
+	}
+	return x, nil
+}
+
+// CfgApply stages every change with cfg.set_delayed_* and commits them
+// together. Staged changes are rolled back if any step fails. Only one
+// transaction runs at a time because kamailio keeps a single delayed set.
+func (a *API) CfgApply(ctx context.Context, changes []CfgChange) error {
+	a.cfgMu.Lock()
+	defer a.cfgMu.Unlock()
+	for _, c := range changes {
+		var err error
+		switch v := c.Value.(type) {
+		case int:
+			err = a.cfgSetDelayedInt(ctx, c.Group, c.Var, v)
+		case string:
+			err = a.cfgSetDelayedString(ctx, c.Group, c.Var, v)
+		default:
+			err = fmt.Errorf("value of %s.%s must be an int or a string", c.Group, c.Var)
+		}
+		if err != nil {
+			if rerr := a.cfgRollback(ctx); rerr != nil {
+				a.logger.Error("could not rollback cfg changes", zap.Error(rerr))
+			}
+			return fmt.Errorf("%s.%s: %w", c.Group, c.Var, err)
+		}
+	}
+	if err := a.cfgCommit(ctx); err != nil {
+		if rerr := a.cfgRollback(ctx); rerr != nil {
+			a.logger.Error("could not rollback cfg changes", zap.Error(rerr))
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package jsonrpcc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type CoreUptimeResult struct {
+	Now     string `json:"now"`
+	UpSince string `json:"up_since"`
+	Uptime  int64  `json:"uptime"`
+}
+
+type CoreInfoResult struct {
+	Version  string `json:"version"`
+	ID       string `json:"id"`
+	Compiler string `json:"compiler"`
+	Compiled string `json:"compiled"`
+	Flags    string `json:"flags"`
+}
+
+type CoreProcess struct {
+	Index       int64  `json:"IDX"`
+	PID         int64  `json:"PID"`
+	Description string `json:"DSC"`
+}
+
+type CoreShmMemResult struct {
+	Total     int64 `json:"total"`
+	Free      int64 `json:"free"`
+	Used      int64 `json:"used"`
+	RealUsed  int64 `json:"real_used"`
+	MaxUsed   int64 `json:"max_used"`
+	Fragments int64 `json:"fragments"`
+}
+
+type CoreTCPInfoResult struct {
+	Readers              int64 `json:"readers"`
+	MaxConnections       int64 `json:"max_connections"`
+	MaxTLSConnections    int64 `json:"max_tls_connections"`
+	OpenedConnections    int64 `json:"opened_connections"`
+	OpenedTLSConnections int64 `json:"opened_tls_connections"`
+	WriteQueuedBytes     int64 `json:"write_queued_bytes"`
+}
+
+type CoreSocket struct {
+	Proto     string `json:"proto"`
+	Address   string `json:"address"`
+	IPAddress string `json:"ipaddress"`
+	Port      string `json:"port"`
+	MCast     string `json:"mcast"`
+	MHomed    string `json:"mhomed"`
+	Advertise string `json:"advertise"`
+	Name      string `json:"sockname"`
+}
+
+func (a *API) CoreVersion(ctx context.Context) (string, error) {
+	a.logger.Debug("core version")
+	x := ""
+	err := a.call(ctx, "core.version", nil, &x)
+	return x, err
+}
+
+func (a *API) CoreUptime(ctx context.Context) (CoreUptimeResult, error) {
+	a.logger.Debug("core uptime")
+	x := CoreUptimeResult{}
+	err := a.call(ctx, "core.uptime", nil, &x)
+	return x, err
+}
+
+func (a *API) CoreInfo(ctx context.Context) (CoreInfoResult, error) {
+	a.logger.Debug("core info")
+	x := CoreInfoResult{}
+	err := a.call(ctx, "core.info", nil, &x)
+	return x, err
+}
+
+// CoreProcesses lists kamailio processes (core.psx)
+func (a *API) CoreProcesses(ctx context.Context) ([]CoreProcess, error) {
+	a.logger.Debug("core psx")
+	x := []CoreProcess{}
+	err := a.call(ctx, "core.psx", nil, &x)
+	return x, err
+}
+
+// CoreShmMem returns shared memory usage. unit is one of b, k, m or g and
+// defaults to bytes when empty.
+func (a *API) CoreShmMem(ctx context.Context, unit string) (CoreShmMemResult, error) {
+	a.logger.Debug("core shmmem", zap.String("unit", unit))
+	var params []interface{}
+	if unit != "" {
+		params = []interface{}{unit}
+	}
+	x := CoreShmMemResult{}
+	err := a.call(ctx, "core.shmmem", params, &x)
+	return x, err
+}
+
+func (a *API) CoreTCPInfo(ctx context.Context) (CoreTCPInfoResult, error) {
+	a.logger.Debug("core tcp info")
+	x := CoreTCPInfoResult{}
+	err := a.call(ctx, "core.tcp_info", nil, &x)
+	return x, err
+}
+
+func (a *API) CoreSockets(ctx context.Context) ([]CoreSocket, error) {
+	a.logger.Debug("core sockets list")
+	// every listener is added under the same "socket" key
+	x := rpcList[CoreSocket]{}
+	if err := a.call(ctx, "core.sockets_list", nil, &x); err != nil {
+		return []CoreSocket{}, err
+	}
+	return x, nil
+}
+
+// CoreModules lists the loaded kamailio modules
+func (a *API) CoreModules(ctx context.Context) ([]string, error) {
+	a.logger.Debug("core modules")
+	x := []string{}
+	err := a.call(ctx, "core.modules", nil, &x)
+	return x, err
+}
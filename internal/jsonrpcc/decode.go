@@ -0,0 +1,91 @@
+package jsonrpcc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// rpcList decodes a list of T from the shapes kamailio uses for repeated
+// entries: a JSON array, a single object, or an object that repeats the same
+// key for every entry (e.g. {"socket":{...},"socket":{...}}). A scalar is a
+// single entry, kamailio replies with a bare string when an rpc prints one
+// line.
+type rpcList[T any] []T
+
+func (l *rpcList[T]) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	if b[0] == '[' {
+		x := []T{}
+		if err := json.Unmarshal(b, &x); err != nil {
+			return err
+		}
+		*l = append(*l, x...)
+		return nil
+	}
+	if b[0] != '{' {
+		var x T
+		if err := json.Unmarshal(b, &x); err != nil {
+			return fmt.Errorf("unexpected list value [%s]", string(b))
+		}
+		*l = append(*l, x)
+		return nil
+	}
+	values, ok, err := wrappedValues(b)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		var x T
+		if err := json.Unmarshal(b, &x); err != nil {
+			return err
+		}
+		*l = append(*l, x)
+		return nil
+	}
+	for _, v := range values {
+		z := rpcList[T]{}
+		if err := json.Unmarshal(v, &z); err != nil {
+			return err
+		}
+		*l = append(*l, z...)
+	}
+	return nil
+}
+
+// wrappedValues returns the values of object b when every value is itself an
+// object or array under the same key, which marks b as a wrapper around list
+// entries rather than a single entry
+func wrappedValues(b []byte) ([]json.RawMessage, bool, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	if _, err := d.Token(); err != nil {
+		return nil, false, err
+	}
+	values := []json.RawMessage{}
+	first := ""
+	for d.More() {
+		k, err := d.Token()
+		if err != nil {
+			return nil, false, err
+		}
+		key, _ := k.(string)
+		if len(values) == 0 {
+			first = key
+		} else if key != first {
+			return nil, false, nil
+		}
+		var raw json.RawMessage
+		if err := d.Decode(&raw); err != nil {
+			return nil, false, err
+		}
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 || (raw[0] != '{' && raw[0] != '[') {
+			return nil, false, nil
+		}
+		values = append(values, raw)
+	}
+	return values, len(values) > 0, nil
+}
@@ -0,0 +1,124 @@
+package jsonrpcc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRPCList(t *testing.T) {
+	type socket struct {
+		Proto   string `json:"proto"`
+		Address string `json:"address"`
+		Port    int    `json:"port"`
+	}
+	type attribute struct {
+		Name  string `json:"Name"`
+		Value string `json:"Value"`
+	}
+	type domain struct {
+		Domain     string             `json:"Domain"`
+		Attributes rpcList[attribute] `json:"Attributes"`
+	}
+	type group struct {
+		Targets []int          `json:"targets"`
+		Attrs   map[string]int `json:"attrs"`
+	}
+	tests := []struct {
+		name    string
+		in      string
+		got     interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "repeated key",
+			in:   `{"socket":{"proto":"udp","address":"10.0.0.1","port":5060},"socket":{"proto":"tcp","address":"10.0.0.1","port":5060}}`,
+			got:  &rpcList[socket]{},
+			want: &rpcList[socket]{{Proto: "udp", Address: "10.0.0.1", Port: 5060}, {Proto: "tcp", Address: "10.0.0.1", Port: 5060}},
+		},
+		{
+			name: "single wrapped entry",
+			in:   `{"socket":{"proto":"udp","address":"10.0.0.1","port":5060}}`,
+			got:  &rpcList[socket]{},
+			want: &rpcList[socket]{{Proto: "udp", Address: "10.0.0.1", Port: 5060}},
+		},
+		{
+			name: "single object",
+			in:   `{"proto":"udp","address":"10.0.0.1","port":5060}`,
+			got:  &rpcList[socket]{},
+			want: &rpcList[socket]{{Proto: "udp", Address: "10.0.0.1", Port: 5060}},
+		},
+		{
+			name: "array",
+			in:   `[{"proto":"udp","address":"10.0.0.1","port":5060},{"proto":"tls","address":"10.0.0.1","port":5061}]`,
+			got:  &rpcList[socket]{},
+			want: &rpcList[socket]{{Proto: "udp", Address: "10.0.0.1", Port: 5060}, {Proto: "tls", Address: "10.0.0.1", Port: 5061}},
+		},
+		{
+			name: "wrapped array",
+			in:   `{"RECORDS":[{"proto":"udp","address":"10.0.0.1","port":5060}]}`,
+			got:  &rpcList[socket]{},
+			want: &rpcList[socket]{{Proto: "udp", Address: "10.0.0.1", Port: 5060}},
+		},
+		{
+			name: "nested attributes",
+			in:   `{"Domain":"example.com","Attributes":{"Attribute":{"Name":"a","Value":"1"},"Attribute":{"Name":"b","Value":"2"}}}`,
+			got:  &rpcList[domain]{},
+			want: &rpcList[domain]{{Domain: "example.com", Attributes: rpcList[attribute]{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}}},
+		},
+		{
+			name: "nested attributes array",
+			in:   `[{"Domain":"example.com","Attributes":[{"Name":"a","Value":"1"}]},{"Domain":"example.org"}]`,
+			got:  &rpcList[domain]{},
+			want: &rpcList[domain]{{Domain: "example.com", Attributes: rpcList[attribute]{{Name: "a", Value: "1"}}}, {Domain: "example.org"}},
+		},
+		{
+			name: "null",
+			in:   `null`,
+			got:  &rpcList[socket]{},
+			want: &rpcList[socket]{},
+		},
+		{
+			name: "entry with only object and array fields",
+			in:   `{"targets":[1,2],"attrs":{"k":1}}`,
+			got:  &rpcList[group]{},
+			want: &rpcList[group]{{Targets: []int{1, 2}, Attrs: map[string]int{"k": 1}}},
+		},
+		{
+			name: "array of entries with only object and array fields",
+			in:   `[{"targets":[1,2],"attrs":{"k":1}}]`,
+			got:  &rpcList[group]{},
+			want: &rpcList[group]{{Targets: []int{1, 2}, Attrs: map[string]int{"k": 1}}},
+		},
+		{
+			name: "single line",
+			in:   `"udp:10.0.0.1:5060"`,
+			got:  &rpcList[string]{},
+			want: &rpcList[string]{"udp:10.0.0.1:5060"},
+		},
+		{
+			name: "lines",
+			in:   `["udp:10.0.0.1:5060","tcp:10.0.0.1:5060"]`,
+			got:  &rpcList[string]{},
+			want: &rpcList[string]{"udp:10.0.0.1:5060", "tcp:10.0.0.1:5060"},
+		},
+		{
+			name:    "scalar for object entries",
+			in:      `"udp:10.0.0.1:5060"`,
+			got:     &rpcList[socket]{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := json.Unmarshal([]byte(tt.in), tt.got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.got, tt.want) {
+				t.Fatalf("Unmarshal() = %+v, want %+v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package jsonrpcc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+// dialplan match operators
+const (
+	DialplanMatchEqual   = 0
+	DialplanMatchRegex   = 1
+	DialplanMatchFnmatch = 2
+)
+
+type DialplanRule struct {
+	Priority int64  `json:"PRIO"`
+	MatchOp  int64  `json:"MATCHOP"`
+	MatchExp string `json:"MATCHEXP"`
+	MatchLen int64  `json:"MATCHLEN"`
+	SubstExp string `json:"SUBSTEXP"`
+	ReplExp  string `json:"REPLEXP"`
+	Attrs    string `json:"ATTRS"`
+}
+
+type DialplanDumpResult struct {
+	DPID    int64          `json:"DPID"`
+	Entries []DialplanRule `json:"ENTRIES"`
+}
+
+type DialplanTranslateResult struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+	Attrs  string `json:"attrs"`
+	// MatchedRule is the first rule, in kamailio's priority order, that
+	// matches the input and carries the returned attrs. It is resolved
+	// locally with Go regexp and is nil when a rule up to the match uses PCRE
+	// only syntax.
+	MatchedRule *DialplanRule `json:"matched_rule,omitempty"`
+}
+
+func (a *API) DialplanDump(ctx context.Context, dpid int) (DialplanDumpResult, error) {
+	a.logger.Debug("dialplan dump", zap.Int("dpid", dpid))
+	// entries come as an array or as an object repeating the ENTRY key
+	type result struct {
+		DPID    int64                 `json:"DPID"`
+		Entries rpcList[DialplanRule] `json:"ENTRIES"`
+	}
+	z := result{Entries: rpcList[DialplanRule]{}}
+	if err := a.call(ctx, "dialplan.dump", []interface{}{dpid}, &z); err != nil {
+		return DialplanDumpResult{}, err
+	}
+	return DialplanDumpResult{DPID: z.DPID, Entries: z.Entries}, nil
+}
+
+func (a *API) DialplanReload(ctx context.Context) error {
+	a.logger.Debug("dialplan reload")
+	return a.call(ctx, "dialplan.reload", nil, nil)
+}
+
+func (a *API) DialplanTranslate(ctx context.Context, dpid int, input string) (DialplanTranslateResult, error) {
+	a.logger.Debug("dialplan translate", zap.Int("dpid", dpid), zap.String("input", input))
+	type result struct {
+		Output string `json:"Output"`
+		Attrs  string `json:"Attributes"`
+	}
+	z := result{}
+	if err := a.call(ctx, "dialplan.translate", []interface{}{dpid, input}, &z); err != nil {
+		return DialplanTranslateResult{}, err
+	}
+	x := DialplanTranslateResult{Input: input, Output: z.Output, Attrs: z.Attrs}
+	d, err := a.DialplanDump(ctx, dpid)
+	if err != nil {
+		a.logger.Info("could not dump dialplan to resolve matched rule", zap.Error(err), zap.Int("dpid", dpid))
+		return x, nil
+	}
+	x.MatchedRule = matchedDialplanRule(d.Entries, input, z.Attrs)
+	return x, nil
+}
+
+// matchedDialplanRule returns the first rule that matches input and carries
+// attrs. It returns nil once a rule cannot be evaluated locally, kamailio may
+// have matched that rule instead of a later one.
+func matchedDialplanRule(rules []DialplanRule, input, attrs string) *DialplanRule {
+	for _, r := range rules {
+		ok, err := dialplanRuleMatches(r, input)
+		if err != nil {
+			return nil
+		}
+		if ok && r.Attrs == attrs {
+			return &r
+		}
+	}
+	return nil
+}
+
+// dialplanRuleMatches fails for an expression Go regexp cannot compile, such
+// as PCRE lookarounds or backreferences
+func dialplanRuleMatches(r DialplanRule, input string) (bool, error) {
+	if r.MatchLen != 0 && int64(len(input)) != r.MatchLen {
+		return false, nil
+	}
+	switch r.MatchOp {
+	case DialplanMatchEqual:
+		return r.MatchExp == input, nil
+	case DialplanMatchRegex:
+		re, err := regexp.Compile(r.MatchExp)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(input), nil
+	case DialplanMatchFnmatch:
+		re, err := fnmatchRegexp(r.MatchExp)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(input), nil
+	}
+	return false, fmt.Errorf("unknown match operator [%d]", r.MatchOp)
+}
+
+// fnmatchRegexp converts a fnmatch(3) pattern, as used by dialplan without
+// flags, to a regexp. Unlike path.Match, * and ? also match /, a bracket
+// expression is negated with ! or ^, and an unterminated [ is literal.
+func fnmatchRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString(`^(?s:`)
+	literal := func(s string) {
+		b.WriteString(regexp.QuoteMeta(s))
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString(`.*`)
+		case '?':
+			b.WriteString(`.`)
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			literal(pattern[i : i+1])
+		case '[':
+			end := fnmatchBracketEnd(pattern, i)
+			if end < 0 {
+				literal("[")
+				continue
+			}
+			class := pattern[i+1 : end]
+			if class[0] == '!' {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i = end
+		default:
+			// copy the whole rune so multi byte characters stay intact
+			_, n := utf8.DecodeRuneInString(pattern[i:])
+			literal(pattern[i : i+n])
+			i += n - 1
+		}
+	}
+	b.WriteString(`)$`)
+	return regexp.Compile(b.String())
+}
+
+// fnmatchBracketEnd returns the index of the ] closing the bracket
+// expression opened at i, or -1
+func fnmatchBracketEnd(pattern string, i int) int {
+	j := i + 1
+	if j < len(pattern) && (pattern[j] == '!' || pattern[j] == '^') {
+		j++
+	}
+	// a leading ] is part of the set
+	if j < len(pattern) && pattern[j] == ']' {
+		j++
+	}
+	for ; j < len(pattern); j++ {
+		switch {
+		case strings.HasPrefix(pattern[j:], "[:"):
+			k := strings.Index(pattern[j+2:], ":]")
+			if k < 0 {
+				return -1
+			}
+			j += k + 3
+		case pattern[j] == ']':
+			return j
+		}
+	}
+	return -1
+}
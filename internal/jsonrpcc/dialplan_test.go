@@ -0,0 +1,84 @@
+package jsonrpcc
+
+import "testing"
+
+func TestFnmatchRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    bool
+	}{
+		{pattern: "00*", input: "0044123", want: true},
+		{pattern: "00*", input: "0144123", want: false},
+		{pattern: "*", input: "sip/trunk", want: true},
+		{pattern: "1?3", input: "123", want: true},
+		{pattern: "1?3", input: "1/3", want: true},
+		{pattern: "[0-9]*", input: "5551234", want: true},
+		{pattern: "[!0-9]*", input: "5551234", want: false},
+		{pattern: "[!0-9]*", input: "+5551234", want: true},
+		{pattern: "[^0-9]*", input: "+5551234", want: true},
+		{pattern: "[]]x", input: "]x", want: true},
+		{pattern: "[[:digit:]]*", input: "9", want: true},
+		{pattern: "[[:digit:]]*", input: "a", want: false},
+		{pattern: "+1.555", input: "+1.555", want: true},
+		{pattern: "+1.555", input: "+1x555", want: false},
+		{pattern: `\*99`, input: "*99", want: true},
+		{pattern: `\*99`, input: "199", want: false},
+		{pattern: "[12", input: "[12", want: true},
+		{pattern: "é*", input: "éa", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.input, func(t *testing.T) {
+			re, err := fnmatchRegexp(tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := re.MatchString(tt.input); got != tt.want {
+				t.Fatalf("fnmatch(%q, %q) = %v, want %v (%s)", tt.pattern, tt.input, got, tt.want, re)
+			}
+		})
+	}
+}
+
+func TestMatchedDialplanRule(t *testing.T) {
+	rules := []DialplanRule{
+		{Priority: 1, MatchOp: DialplanMatchEqual, MatchExp: "911", Attrs: "emergency"},
+		{Priority: 2, MatchOp: DialplanMatchRegex, MatchExp: `^\+1(\d{10})$`, Attrs: "nanp"},
+		{Priority: 3, MatchOp: DialplanMatchFnmatch, MatchExp: "00*", Attrs: "intl"},
+		{Priority: 4, MatchOp: DialplanMatchRegex, MatchExp: `^(?!800)\d{7}$`, Attrs: ""},
+		{Priority: 5, MatchOp: DialplanMatchRegex, MatchExp: `^\d+$`, MatchLen: 4, Attrs: ""},
+		{Priority: 6, MatchOp: DialplanMatchRegex, MatchExp: `^.*$`, Attrs: ""},
+	}
+	tests := []struct {
+		name  string
+		input string
+		attrs string
+		want  int64
+	}{
+		{name: "equal", input: "911", attrs: "emergency", want: 1},
+		{name: "regex", input: "+12125551234", attrs: "nanp", want: 2},
+		{name: "fnmatch", input: "0044123", attrs: "intl", want: 3},
+		{name: "pcre rule after a skipped rule", input: "911", attrs: "", want: 0},
+		{name: "pcre rule above the match", input: "1234", attrs: "", want: 0},
+		{name: "no match", input: "911", attrs: "other", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchedDialplanRule(rules, tt.input, tt.attrs)
+			if tt.want == 0 {
+				if got != nil {
+					t.Fatalf("matchedDialplanRule() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Priority != tt.want {
+				t.Fatalf("matchedDialplanRule() = %+v, want rule %d", got, tt.want)
+			}
+		})
+	}
+	// the pcre rule cannot be the match when an earlier rule matches
+	rules[0].Attrs = ""
+	if got := matchedDialplanRule(rules, "911", ""); got == nil || got.Priority != 1 {
+		t.Fatalf("matchedDialplanRule() = %+v, want rule 1", got)
+	}
+}
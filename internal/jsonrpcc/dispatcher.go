@@ -53,17 +53,16 @@ func (a *API) dispatcherList(ctx context.Context, rmode string) (DispatcherListR
 	if err != nil {
 		return DispatcherListResult{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return DispatcherListResult{}, err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return DispatcherListResult{}, err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("status code", zap.Int("res.StatusCode", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
@@ -119,17 +118,16 @@ func (a *API) DispatcherAdd(ctx context.Context, group string, addr string, flag
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("unexpected status code", zap.Int("status code", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
@@ -166,17 +164,16 @@ func (a *API) DispatcherRemove(ctx context.Context, group string, addr string) e
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("unexpected status code", zap.Int("status code", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
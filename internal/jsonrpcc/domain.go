@@ -0,0 +1,94 @@
+package jsonrpcc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+type DomainAttribute struct {
+	Name  string          `json:"name"`
+	Type  int64           `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+type Domain struct {
+	Domain     string            `json:"domain"`
+	DID        string            `json:"did"`
+	Attributes []DomainAttribute `json:"attributes"`
+}
+
+type CoreAlias struct {
+	Host  string `json:"host"`
+	Port  int64  `json:"port"`
+	Proto string `json:"proto"`
+}
+
+// DomainDump returns the domains loaded by the domain module with their
+// attributes
+func (a *API) DomainDump(ctx context.Context) ([]Domain, error) {
+	a.logger.Debug("domain dump")
+	type attribute struct {
+		Name  string          `json:"Name"`
+		Type  int64           `json:"Type"`
+		Value json.RawMessage `json:"Value"`
+	}
+	type domain struct {
+		Domain     string             `json:"Domain"`
+		DID        string             `json:"Did"`
+		Attributes rpcList[attribute] `json:"Attributes"`
+	}
+	z := rpcList[domain]{}
+	if err := a.call(ctx, "domain.dump", nil, &z); err != nil {
+		return []Domain{}, err
+	}
+	x := make([]Domain, 0, len(z))
+	for _, d := range z {
+		attrs := make([]DomainAttribute, 0, len(d.Attributes))
+		for _, v := range d.Attributes {
+			attrs = append(attrs, DomainAttribute(v))
+		}
+		x = append(x, Domain{Domain: d.Domain, DID: d.DID, Attributes: attrs})
+	}
+	return x, nil
+}
+
+// DomainReload reloads the domain and domain_attrs tables
+func (a *API) DomainReload(ctx context.Context) error {
+	a.logger.Debug("domain reload")
+	return a.call(ctx, "domain.reload", nil, nil)
+}
+
+// CorexListAliases returns the alias= hosts kamailio treats as local
+func (a *API) CorexListAliases(ctx context.Context) ([]CoreAlias, error) {
+	a.logger.Debug("corex list aliases")
+	z := json.RawMessage{}
+	if err := a.call(ctx, "corex.list_aliases", nil, &z); err != nil {
+		return []CoreAlias{}, err
+	}
+	return parseCoreAliases(z)
+}
+
+// parseCoreAliases decodes corex.list_aliases, one {"alias":{...}} object per
+// alias, or the bare object when there is a single one
+func parseCoreAliases(b json.RawMessage) ([]CoreAlias, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] != '[' {
+		x := rpcList[CoreAlias]{}
+		if err := json.Unmarshal(b, &x); err != nil {
+			return []CoreAlias{}, err
+		}
+		return x, nil
+	}
+	z := []struct {
+		Alias CoreAlias `json:"alias"`
+	}{}
+	if err := json.Unmarshal(b, &z); err != nil {
+		return []CoreAlias{}, err
+	}
+	x := make([]CoreAlias, 0, len(z))
+	for _, v := range z {
+		x = append(x, v.Alias)
+	}
+	return x, nil
+}
@@ -0,0 +1,42 @@
+package jsonrpcc
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestParseCoreAliases(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []CoreAlias
+	}{
+		{
+			name: "several aliases",
+			in:   `[{"alias":{"host":"sip.example.com","port":5060,"proto":"udp"}},{"alias":{"host":"10.0.0.1","port":5061,"proto":"tls"}}]`,
+			want: []CoreAlias{{Host: "sip.example.com", Port: 5060, Proto: "udp"}, {Host: "10.0.0.1", Port: 5061, Proto: "tls"}},
+		},
+		{
+			name: "single alias",
+			in:   `{"alias":{"host":"sip.example.com","port":5060,"proto":"udp"}}`,
+			want: []CoreAlias{{Host: "sip.example.com", Port: 5060, Proto: "udp"}},
+		},
+		{
+			name: "no aliases",
+			in:   `null`,
+			want: []CoreAlias{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCoreAliases(json.RawMessage(tt.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parseCoreAliases() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package jsonrpcc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type DroutingGateway struct {
+	ID      string `json:"id"`
+	Address string `json:"address"`
+	Type    int64  `json:"type"`
+	Strip   int64  `json:"strip"`
+	Prefix  string `json:"prefix"`
+	Attrs   string `json:"attrs"`
+	State   string `json:"state"`
+}
+
+type DroutingRule struct {
+	ID       string `json:"id"`
+	Groups   string `json:"groups"`
+	Prefix   string `json:"prefix"`
+	Priority int64  `json:"priority"`
+	Gateways string `json:"gwlist"`
+	Attrs    string `json:"attrs"`
+	State    string `json:"state"`
+}
+
+type DroutingDumpResult struct {
+	Gateways []DroutingGateway `json:"gateways"`
+	Rules    []DroutingRule    `json:"rules"`
+}
+
+// drouting states accepted by gw_status and rule_status
+const (
+	DroutingEnabled  = 1
+	DroutingDisabled = 0
+)
+
+func (a *API) DroutingReload(ctx context.Context) error {
+	a.logger.Debug("drouting reload")
+	return a.call(ctx, "drouting.reload", nil, nil)
+}
+
+func (a *API) DroutingDump(ctx context.Context) (DroutingDumpResult, error) {
+	a.logger.Debug("drouting dump")
+	type result struct {
+		Gateways rpcList[DroutingGateway] `json:"gateways"`
+		Rules    rpcList[DroutingRule]    `json:"rules"`
+	}
+	z := result{Gateways: rpcList[DroutingGateway]{}, Rules: rpcList[DroutingRule]{}}
+	if err := a.call(ctx, "drouting.dump", nil, &z); err != nil {
+		return DroutingDumpResult{}, err
+	}
+	return DroutingDumpResult{Gateways: z.Gateways, Rules: z.Rules}, nil
+}
+
+// DroutingGatewayStatus lists gateways with their state, or only gateway id
+// when id is not empty
+func (a *API) DroutingGatewayStatus(ctx context.Context, id string) ([]DroutingGateway, error) {
+	a.logger.Debug("drouting gw status", zap.String("id", id))
+	var params []interface{}
+	if id != "" {
+		params = []interface{}{id}
+	}
+	x := rpcList[DroutingGateway]{}
+	if err := a.call(ctx, "drouting.gw_status", params, &x); err != nil {
+		return []DroutingGateway{}, err
+	}
+	return x, nil
+}
+
+// DroutingSetGatewayStatus enables or disables gateway id
+func (a *API) DroutingSetGatewayStatus(ctx context.Context, id string, enabled bool) error {
+	a.logger.Debug("drouting set gw status", zap.String("id", id), zap.Bool("enabled", enabled))
+	return a.call(ctx, "drouting.gw_status", []interface{}{id, droutingState(enabled)}, nil)
+}
+
+// DroutingRuleStatus lists rules with their state, or only rule id when id
+// is not empty
+func (a *API) DroutingRuleStatus(ctx context.Context, id string) ([]DroutingRule, error) {
+	a.logger.Debug("drouting rule status", zap.String("id", id))
+	var params []interface{}
+	if id != "" {
+		params = []interface{}{id}
+	}
+	x := rpcList[DroutingRule]{}
+	if err := a.call(ctx, "drouting.rule_status", params, &x); err != nil {
+		return []DroutingRule{}, err
+	}
+	return x, nil
+}
+
+// DroutingSetRuleStatus enables or disables rule id
+func (a *API) DroutingSetRuleStatus(ctx context.Context, id string, enabled bool) error {
+	a.logger.Debug("drouting set rule status", zap.String("id", id), zap.Bool("enabled", enabled))
+	return a.call(ctx, "drouting.rule_status", []interface{}{id, droutingState(enabled)}, nil)
+}
+
+func droutingState(enabled bool) int {
+	if enabled {
+		return DroutingEnabled
+	}
+	return DroutingDisabled
+}
@@ -88,17 +88,16 @@ func (a *API) htableDump(ctx context.Context, tableName string) ([]HTableDumpRes
 	if err != nil {
 		return []HTableDumpResult{}, err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return nil, err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return []HTableDumpResult{}, err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("unexpected status code", zap.Int("status code", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
@@ -153,17 +152,16 @@ func (a *API) HTableSets(ctx context.Context, tableName string, key string, valu
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("unexpected status code", zap.Int("status code", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
@@ -200,17 +198,16 @@ func (a *API) HTableGet(ctx context.Context, tableName string, key string) (stri
 	if err != nil {
 		return "", err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return "", err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return "", err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("unexpected status code", zap.Int("status code", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
@@ -263,17 +260,16 @@ func (a *API) htableFlush(ctx context.Context, tableName string) error {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("unexpected status code", zap.Int("status code", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
@@ -326,17 +322,16 @@ func (a *API) htableDelete(ctx context.Context, tableName string, key string) er
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode == http.StatusNotFound {
 		a.logger.Debug("key not found")
 		return nil
@@ -356,6 +351,29 @@ func (a *API) HTableDelete(ctx context.Context, tableName string, key string) er
 	return a.htableDelete(ctx, tableName, key)
 }
 
+// HTableDeleteKeys deletes keys in batches and returns the error of each key
+// by index, the error is set when a batch request failed
+func (a *API) HTableDeleteKeys(ctx context.Context, tableName string, keys []string) ([]error, error) {
+	a.logger.Debug("htable delete keys", zap.String("table name", tableName), zap.Int("keys", len(keys)))
+	type params struct {
+		TableName string `json:"htable"`
+		Key       string `json:"key"`
+	}
+	calls := make([]Call, 0, len(keys))
+	for _, k := range keys {
+		calls = append(calls, Call{Method: "htable.delete", Params: params{TableName: tableName, Key: k}})
+	}
+	x, err := a.Batch(ctx, calls...)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(x))
+	for i, v := range x {
+		errs[i] = v.Err
+	}
+	return errs, nil
+}
+
 func htableResultQueryKeyContains(ctx context.Context, h HTableDumpResult, value string) bool {
 	for _, v := range h.Slot {
 		if !strings.Contains(v.Name, value) {
@@ -415,3 +433,9 @@ func (a *API) HTableQueryKeyContains(ctx context.Context, tableName string, valu
 func (a *API) HTableQueryValueContains(ctx context.Context, tableName string, value string) ([]HTableDumpResult, error) {
 	return a.htableQueryValueContains(ctx, tableName, value)
 }
+
+// HTableSetex sets the expire of an existing item to expire seconds from now
+func (a *API) HTableSetex(ctx context.Context, tableName string, key string, expire int) error {
+	a.logger.Debug("htable setex", zap.String("table name", tableName), zap.String("key", key), zap.Int("expire", expire))
+	return a.call(ctx, "htable.setex", []interface{}{tableName, key, expire}, nil)
+}
@@ -0,0 +1,248 @@
+package jsonrpcc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MaxIPBanImport caps how many addresses a CIDR import may expand to
+const MaxIPBanImport = 65536
+
+// IPBan is an address banned through an htable keyed by IP, the usual
+// $sht(ipban=>$si) pattern in kamailio configs
+type IPBan struct {
+	IP               string    `json:"ip"`
+	Reason           string    `json:"reason"`
+	BannedAt         time.Time `json:"banned_at,omitzero"`
+	ExpiresAt        time.Time `json:"expires_at,omitzero"`
+	RemainingSeconds int64     `json:"remaining_seconds,omitempty"`
+}
+
+// ipBanValue is stored as the htable value so bans can be listed with their
+// reason and expiry, htable.dump does not return item expires
+type ipBanValue struct {
+	Reason    string `json:"reason"`
+	BannedAt  int64  `json:"banned_at"`
+	ExpiresAt int64  `json:"expires_at,omitzero"`
+}
+
+// BanIP bans ip for ttl. A zero ttl leaves expiry to the table's autoexpire.
+func (a *API) BanIP(ctx context.Context, tableName string, ip string, reason string, ttl time.Duration) error {
+	a.logger.Debug("ban ip", zap.String("table name", tableName), zap.String("ip", ip), zap.String("reason", reason), zap.Duration("ttl", ttl))
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return fmt.Errorf("invalid ip [%s]", ip)
+	}
+	v, err := newIPBanValue(reason, ttl)
+	if err != nil {
+		return err
+	}
+	if err := a.HTableSets(ctx, tableName, addr.String(), v); err != nil {
+		return err
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	if err := a.HTableSetex(ctx, tableName, addr.String(), ttlSeconds(ttl)); err != nil {
+		a.unbanAfterSetexFailure(ctx, tableName, []string{addr.String()})
+		return err
+	}
+	return nil
+}
+
+// BanIPs bans ips in batches and returns the error of each ip by index, the
+// error is set when a batch request failed
+func (a *API) BanIPs(ctx context.Context, tableName string, ips []string, reason string, ttl time.Duration) ([]error, error) {
+	a.logger.Debug("ban ips", zap.String("table name", tableName), zap.Int("ips", len(ips)), zap.String("reason", reason), zap.Duration("ttl", ttl))
+	v, err := newIPBanValue(reason, ttl)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(ips))
+	type params struct {
+		TableName string `json:"htable"`
+		Key       string `json:"key"`
+		Value     string `json:"value"`
+	}
+	calls := []Call{}
+	keys := []string{}
+	index := []int{}
+	for i, ip := range ips {
+		addr := net.ParseIP(ip)
+		if addr == nil {
+			errs[i] = fmt.Errorf("invalid ip [%s]", ip)
+			continue
+		}
+		calls = append(calls, Call{Method: "htable.sets", Params: params{TableName: tableName, Key: addr.String(), Value: v}})
+		keys = append(keys, addr.String())
+		index = append(index, i)
+	}
+	x, err := a.Batch(ctx, calls...)
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		for j, r := range x {
+			errs[index[j]] = r.Err
+		}
+		return errs, nil
+	}
+	// setex needs the item to exist, so it runs once sets is done
+	expire := []Call{}
+	expireKeys := []string{}
+	expireIndex := []int{}
+	for j, r := range x {
+		if r.Err != nil {
+			errs[index[j]] = r.Err
+			continue
+		}
+		expire = append(expire, Call{Method: "htable.setex", Params: []interface{}{tableName, keys[j], ttlSeconds(ttl)}})
+		expireKeys = append(expireKeys, keys[j])
+		expireIndex = append(expireIndex, index[j])
+	}
+	x, err = a.Batch(ctx, expire...)
+	if err != nil {
+		a.unbanAfterSetexFailure(ctx, tableName, expireKeys)
+		return nil, err
+	}
+	failed := []string{}
+	for j, r := range x {
+		errs[expireIndex[j]] = r.Err
+		if r.Err != nil {
+			failed = append(failed, expireKeys[j])
+		}
+	}
+	a.unbanAfterSetexFailure(ctx, tableName, failed)
+	return errs, nil
+}
+
+const unbanTimeout = 10 * time.Second
+
+// unbanAfterSetexFailure deletes bans whose ttl could not be set. Left in
+// place they would never expire in kamailio while ListIPBans hides them
+// once their stored expires_at passes.
+func (a *API) unbanAfterSetexFailure(ctx context.Context, tableName string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	// the cleanup must run even when the caller went away
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unbanTimeout)
+	defer cancel()
+	errs, err := a.HTableDeleteKeys(ctx, tableName, keys)
+	if err != nil {
+		a.logger.Error("could not delete bans without ttl", zap.Error(err), zap.String("table name", tableName), zap.Strings("ips", keys))
+		return
+	}
+	for i, err := range errs {
+		if err != nil {
+			a.logger.Error("could not delete ban without ttl", zap.Error(err), zap.String("table name", tableName), zap.String("ip", keys[i]))
+		}
+	}
+}
+
+// ttlSeconds rounds ttl up to whole seconds for htable.setex, where 0 would
+// mean the item never expires
+func ttlSeconds(ttl time.Duration) int {
+	return int((ttl + time.Second - 1) / time.Second)
+}
+
+func newIPBanValue(reason string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	v := ipBanValue{Reason: reason, BannedAt: now.Unix()}
+	if ttl > 0 {
+		v.ExpiresAt = now.Unix() + int64(ttlSeconds(ttl))
+	}
+	b, err := json.Marshal(&v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (a *API) UnbanIP(ctx context.Context, tableName string, ip string) error {
+	a.logger.Debug("unban ip", zap.String("table name", tableName), zap.String("ip", ip))
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return fmt.Errorf("invalid ip [%s]", ip)
+	}
+	return a.HTableDelete(ctx, tableName, addr.String())
+}
+
+// ListIPBans returns the active bans. Items written by kamailio itself carry
+// no expiry and report their raw value as the reason.
+func (a *API) ListIPBans(ctx context.Context, tableName string) ([]IPBan, error) {
+	h, err := a.HTableDump(ctx, tableName)
+	if err != nil {
+		return []IPBan{}, err
+	}
+	return ipBans(h, time.Now()), nil
+}
+
+// ipBans returns the bans in h that are active at now
+func ipBans(h []HTableDumpResult, now time.Time) []IPBan {
+	x := []IPBan{}
+	for _, r := range h {
+		for _, s := range r.Slot {
+			b := IPBan{IP: s.Name, Reason: s.Value}
+			v := ipBanValue{}
+			if err := json.Unmarshal([]byte(s.Value), &v); err == nil {
+				b.Reason = v.Reason
+				b.BannedAt = time.Unix(v.BannedAt, 0).UTC()
+				if v.ExpiresAt > 0 {
+					b.ExpiresAt = time.Unix(v.ExpiresAt, 0).UTC()
+					if !b.ExpiresAt.After(now) {
+						// expired but not yet swept by the htable timer
+						continue
+					}
+					b.RemainingSeconds = int64(ttlSeconds(b.ExpiresAt.Sub(now)))
+				}
+			}
+			x = append(x, b)
+		}
+	}
+	return x
+}
+
+// ExpandCIDRs returns every address in cidrs. Plain addresses are accepted
+// as single entries. It fails when the total exceeds MaxIPBanImport.
+func ExpandCIDRs(cidrs []string) ([]string, error) {
+	x := []string{}
+	for _, c := range cidrs {
+		if ip := net.ParseIP(c); ip != nil {
+			if len(x) >= MaxIPBanImport {
+				return nil, fmt.Errorf("import expands to more than %d addresses", MaxIPBanImport)
+			}
+			x = append(x, ip.String())
+			continue
+		}
+		ip, n, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr [%s]", c)
+		}
+		ones, bits := n.Mask.Size()
+		if bits-ones > 16 || len(x)+(1<<(bits-ones)) > MaxIPBanImport {
+			return nil, fmt.Errorf("import expands to more than %d addresses", MaxIPBanImport)
+		}
+		for ip := ip.Mask(n.Mask); n.Contains(ip); ip = nextIP(ip) {
+			x = append(x, ip.String())
+		}
+	}
+	return x, nil
+}
+
+func nextIP(ip net.IP) net.IP {
+	x := make(net.IP, len(ip))
+	copy(x, ip)
+	for i := len(x) - 1; i >= 0; i-- {
+		x[i]++
+		if x[i] != 0 {
+			break
+		}
+	}
+	return x
+}
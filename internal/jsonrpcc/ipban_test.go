@@ -0,0 +1,94 @@
+package jsonrpcc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTTLSeconds(t *testing.T) {
+	tests := []struct {
+		ttl  time.Duration
+		want int
+	}{
+		{ttl: 500 * time.Millisecond, want: 1},
+		{ttl: time.Second, want: 1},
+		{ttl: 1900 * time.Millisecond, want: 2},
+		{ttl: 30 * time.Minute, want: 1800},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ttl.String(), func(t *testing.T) {
+			if got := ttlSeconds(tt.ttl); got != tt.want {
+				t.Fatalf("ttlSeconds(%s) = %d, want %d", tt.ttl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandCIDRs(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "addresses", in: []string{"10.0.0.1", "2001:db8::1"}, want: []string{"10.0.0.1", "2001:db8::1"}},
+		{name: "ipv4 cidr", in: []string{"10.0.0.0/30"}, want: []string{"10.0.0.0", "10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+		{name: "unaligned cidr", in: []string{"10.0.0.5/31"}, want: []string{"10.0.0.4", "10.0.0.5"}},
+		{name: "octet carry", in: []string{"10.0.0.255/32", "10.0.0.254/31"}, want: []string{"10.0.0.255", "10.0.0.254", "10.0.0.255"}},
+		{name: "ipv6 cidr", in: []string{"2001:db8::/127"}, want: []string{"2001:db8::", "2001:db8::1"}},
+		{name: "largest cidr", in: []string{"10.0.0.0/16"}, want: nil},
+		{name: "cidr too large", in: []string{"10.0.0.0/15"}, wantErr: true},
+		{name: "total too large", in: []string{"10.0.0.0/16", "10.1.0.1"}, wantErr: true},
+		{name: "invalid", in: []string{"10.0.0.0/33"}, wantErr: true},
+		{name: "hostname", in: []string{"example.com"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandCIDRs(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandCIDRs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.want == nil {
+				if len(got) != MaxIPBanImport || got[0] != "10.0.0.0" || got[len(got)-1] != "10.0.255.255" {
+					t.Fatalf("ExpandCIDRs() returned %d addresses from %s to %s", len(got), got[0], got[len(got)-1])
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ExpandCIDRs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPBans(t *testing.T) {
+	now := time.Unix(1700000000, 250*int64(time.Millisecond))
+	slot := func(ip, value string) htableSlot {
+		return htableSlot{Name: ip, Value: value, Type: "str"}
+	}
+	h := []HTableDumpResult{
+		{Entry: 1, Slot: []htableSlot{
+			slot("10.0.0.1", `{"reason":"scanner","banned_at":1699999000,"expires_at":1700003600}`),
+			slot("10.0.0.2", `{"reason":"expired","banned_at":1699990000,"expires_at":1699999999}`),
+		}},
+		{Entry: 7, Slot: []htableSlot{
+			slot("10.0.0.3", `{"reason":"ends now","banned_at":1699990000,"expires_at":1700000000}`),
+			slot("10.0.0.4", `{"reason":"last second","banned_at":1699990000,"expires_at":1700000001}`),
+			slot("10.0.0.5", `{"reason":"no ttl","banned_at":1699990000}`),
+			slot("10.0.0.6", "1"),
+		}},
+	}
+	want := []IPBan{
+		{IP: "10.0.0.1", Reason: "scanner", BannedAt: time.Unix(1699999000, 0).UTC(), ExpiresAt: time.Unix(1700003600, 0).UTC(), RemainingSeconds: 3600},
+		{IP: "10.0.0.4", Reason: "last second", BannedAt: time.Unix(1699990000, 0).UTC(), ExpiresAt: time.Unix(1700000001, 0).UTC(), RemainingSeconds: 1},
+		{IP: "10.0.0.5", Reason: "no ttl", BannedAt: time.Unix(1699990000, 0).UTC()},
+		{IP: "10.0.0.6", Reason: "1"},
+	}
+	if got := ipBans(h, now); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ipBans() = %+v, want %+v", got, want)
+	}
+}
@@ -1,10 +1,15 @@
 package jsonrpcc
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,20 +19,89 @@ import (
 type API struct {
 	httpClient      *http.Client
 	jsonrpcHTTPAddr string
+	cfgMu           *sync.Mutex
+	methods         *rpcMethods
 	logger          *zap.Logger
 }
 
-func New(httpURL string, l *zap.Logger) (API, error) {
+// Options configures the http client used to reach kamailio. Zero values
+// take the defaults below.
+type Options struct {
+	// Timeout caps a whole call, shorter deadlines come from the call ctx
+	Timeout time.Duration
+	// DisableKeepAlives opens a new connection per call
+	DisableKeepAlives     bool
+	MaxIdleConns          int
+	MaxIdleConnsPerHost   int
+	IdleConnTimeout       time.Duration
+	DialTimeout           time.Duration
+	KeepAlive             time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+}
+
+func New(httpURL string, o Options, l *zap.Logger) (API, error) {
 	s := API{
 		jsonrpcHTTPAddr: httpURL,
+		cfgMu:           &sync.Mutex{},
+		methods:         &rpcMethods{},
 		logger:          l,
 	}
+	if o.Timeout == 0 {
+		o.Timeout = 60 * time.Second
+	}
+	if o.MaxIdleConns == 0 {
+		o.MaxIdleConns = 100
+	}
+	if o.MaxIdleConnsPerHost == 0 {
+		o.MaxIdleConnsPerHost = o.MaxIdleConns
+	}
+	if o.IdleConnTimeout == 0 {
+		o.IdleConnTimeout = 90 * time.Second
+	}
+	if o.DialTimeout == 0 {
+		o.DialTimeout = 5 * time.Second
+	}
+	if o.KeepAlive == 0 {
+		o.KeepAlive = 30 * time.Second
+	}
+	if o.TLSHandshakeTimeout == 0 {
+		o.TLSHandshakeTimeout = 10 * time.Second
+	}
+	d := &net.Dialer{
+		Timeout:   o.DialTimeout,
+		KeepAlive: o.KeepAlive,
+	}
+	// every call goes to the same kamailio, so the idle pool is per host
+	t := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           d.DialContext,
+		DisableKeepAlives:     o.DisableKeepAlives,
+		MaxIdleConns:          o.MaxIdleConns,
+		MaxIdleConnsPerHost:   o.MaxIdleConnsPerHost,
+		IdleConnTimeout:       o.IdleConnTimeout,
+		TLSHandshakeTimeout:   o.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: o.ResponseHeaderTimeout,
+		ForceAttemptHTTP2:     true,
+	}
 	s.httpClient = &http.Client{
-		Timeout: 60 * time.Second,
+		Transport: t,
+		Timeout:   o.Timeout,
 	}
 	return s, nil
 }
 
+// URL returns the kamailio jsonrpc endpoint this API talks to
+func (a *API) URL() string {
+	return a.jsonrpcHTTPAddr
+}
+
+// closeBody drains and closes b so the connection goes back to the idle pool
+func closeBody(b io.ReadCloser) {
+	io.Copy(io.Discard, b)
+	b.Close()
+}
+
 func generateUUID(key string) string {
 	c := []byte(key)
 	h := sha256.New()
@@ -35,6 +109,16 @@ func generateUUID(key string) string {
 	return uuid.NewHash(h, uuid.UUID{}, c, 1).String()
 }
 
+// RPCError is a fault returned by kamailio
+type RPCError struct {
+	Code    int
+	Message string
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("message [%s] code [%d]", e.Message, e.Code)
+}
+
 func jsonRPCError(x []byte) error {
 	type jsonErr struct {
 		JSONRPC string `json:"jsonrpc"`
@@ -51,5 +135,66 @@ func jsonRPCError(x []byte) error {
 	if e.Error.Code == 0 {
 		return nil
 	}
-	return fmt.Errorf("message [%s] code [%d]", e.Error.Message, e.Error.Code)
+	return &RPCError{Code: e.Error.Code, Message: e.Error.Message}
+}
+
+// call posts a jsonrpc request for method with positional params and decodes
+// the result into result. params and result may be nil.
+func (a *API) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	type request struct {
+		JSONRPC string        `json:"jsonrpc"`
+		Method  string        `json:"method"`
+		Params  []interface{} `json:"params,omitempty"`
+		ID      string        `json:"id"`
+	}
+	r := request{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      uuid.New().String(),
+	}
+	b, err := json.Marshal(&r)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer closeBody(res.Body)
+	x, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		a.logger.Debug("unexpected status code", zap.String("method", method), zap.Int("status code", res.StatusCode))
+		if err := jsonRPCError(x); err != nil {
+			return err
+		}
+		return fmt.Errorf("unexpected status code [%d]", res.StatusCode)
+	}
+	if err := jsonRPCError(x); err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	type response struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Result  json.RawMessage `json:"result"`
+		ID      string          `json:"id"`
+	}
+	z := response{}
+	if err = json.Unmarshal(x, &z); err != nil {
+		return err
+	}
+	if len(z.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(z.Result, result)
 }
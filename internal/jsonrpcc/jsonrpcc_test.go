@@ -0,0 +1,43 @@
+package jsonrpcc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// BenchmarkCall compares a new connection per call, the behavior before the
+// transport was configurable, with keep-alive against a local fake kamailio
+func BenchmarkCall(b *testing.B) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":"kamailio 5.8.0 (x86_64/linux)","id":"1"}`))
+	}))
+	defer s.Close()
+	tests := []struct {
+		name string
+		o    Options
+	}{
+		{name: "close", o: Options{DisableKeepAlives: true}},
+		{name: "keepalive", o: Options{}},
+	}
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			a, err := New(s.URL, tt.o, zap.NewNop())
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := a.CoreVersion(context.Background()); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package jsonrpcc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type LCRGateway struct {
+	LCRID        int64  `json:"lcr_id"`
+	GwID         int64  `json:"gw_id"`
+	GwIndex      int64  `json:"gw_index"`
+	GwName       string `json:"gw_name"`
+	Scheme       string `json:"scheme"`
+	IPAddr       string `json:"ip_addr"`
+	Hostname     string `json:"hostname"`
+	Port         int64  `json:"port"`
+	Params       string `json:"params"`
+	Transport    string `json:"transport"`
+	Strip        int64  `json:"strip"`
+	Prefix       string `json:"prefix"`
+	Tag          string `json:"tag"`
+	Flags        int64  `json:"flags"`
+	State        int64  `json:"state"`
+	DefunctUntil int64  `json:"defunct_until"`
+}
+
+type LCRRuleTarget struct {
+	GwID     int64 `json:"gw_id"`
+	Priority int64 `json:"priority"`
+	Weight   int64 `json:"weight"`
+}
+
+type LCRRule struct {
+	LCRID      int64           `json:"lcr_id"`
+	RuleID     int64           `json:"rule_id"`
+	Prefix     string          `json:"prefix"`
+	FromURI    string          `json:"from_uri"`
+	RequestURI string          `json:"request_uri"`
+	MTTValue   string          `json:"mt_tvalue"`
+	Stopper    int64           `json:"stopper"`
+	Targets    []LCRRuleTarget `json:"targets"`
+}
+
+func (a *API) LCRDumpGateways(ctx context.Context) ([]LCRGateway, error) {
+	a.logger.Debug("lcr dump gws")
+	x := rpcList[LCRGateway]{}
+	if err := a.call(ctx, "lcr.dump_gws", nil, &x); err != nil {
+		return []LCRGateway{}, err
+	}
+	return x, nil
+}
+
+func (a *API) LCRDumpRules(ctx context.Context) ([]LCRRule, error) {
+	a.logger.Debug("lcr dump rules")
+	type rule struct {
+		LCRRule
+		Targets rpcList[LCRRuleTarget] `json:"targets"`
+	}
+	z := rpcList[rule]{}
+	if err := a.call(ctx, "lcr.dump_rules", nil, &z); err != nil {
+		return []LCRRule{}, err
+	}
+	x := []LCRRule{}
+	for _, v := range z {
+		r := v.LCRRule
+		r.Targets = []LCRRuleTarget(v.Targets)
+		if r.Targets == nil {
+			r.Targets = []LCRRuleTarget{}
+		}
+		x = append(x, r)
+	}
+	return x, nil
+}
+
+func (a *API) LCRReload(ctx context.Context) error {
+	a.logger.Debug("lcr reload")
+	return a.call(ctx, "lcr.reload", nil, nil)
+}
+
+// LCRDefunctGateway takes gateway gwID of lcr instance lcrID out of use for period seconds
+func (a *API) LCRDefunctGateway(ctx context.Context, lcrID int64, gwID int64, period int64) error {
+	a.logger.Debug("lcr defunct gw", zap.Int64("lcr_id", lcrID), zap.Int64("gw_id", gwID), zap.Int64("period", period))
+	return a.call(ctx, "lcr.defunct_gw", []interface{}{lcrID, gwID, period}, nil)
+}
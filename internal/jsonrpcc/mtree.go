@@ -0,0 +1,53 @@
+package jsonrpcc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type MtreeEntry struct {
+	Table  string `json:"table"`
+	Prefix string `json:"prefix"`
+	Value  string `json:"value"`
+}
+
+type MtreeMatchResult struct {
+	Table  string `json:"tname"`
+	Prefix string `json:"tprefix"`
+	Value  string `json:"tvalue"`
+}
+
+// MtreeList returns the prefixes of tree table, or of every tree when table
+// is empty
+func (a *API) MtreeList(ctx context.Context, table string) ([]MtreeEntry, error) {
+	a.logger.Debug("mtree list", zap.String("table", table))
+	var params []interface{}
+	if table != "" {
+		params = []interface{}{table}
+	}
+	x := rpcList[MtreeEntry]{}
+	if err := a.call(ctx, "mtree.list", params, &x); err != nil {
+		return []MtreeEntry{}, err
+	}
+	return x, nil
+}
+
+// MtreeMatch returns the longest prefix of tree table matching prefix
+func (a *API) MtreeMatch(ctx context.Context, table, prefix string) (MtreeMatchResult, error) {
+	a.logger.Debug("mtree match", zap.String("table", table), zap.String("prefix", prefix))
+	x := MtreeMatchResult{}
+	// mode 0 returns the value of the longest match
+	err := a.call(ctx, "mtree.match", []interface{}{table, prefix, 0}, &x)
+	return x, err
+}
+
+// MtreeReload reloads tree table, or every tree when table is empty
+func (a *API) MtreeReload(ctx context.Context, table string) error {
+	a.logger.Debug("mtree reload", zap.String("table", table))
+	var params []interface{}
+	if table != "" {
+		params = []interface{}{table}
+	}
+	return a.call(ctx, "mtree.reload", params, nil)
+}
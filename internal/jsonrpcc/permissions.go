@@ -0,0 +1,145 @@
+package jsonrpcc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+)
+
+type PermissionsAddress struct {
+	Group int64  `json:"group"`
+	IP    string `json:"ip"`
+	Port  int64  `json:"port"`
+	Tag   string `json:"tag"`
+}
+
+type PermissionsSubnet struct {
+	ID    int64  `json:"id"`
+	Group int64  `json:"group"`
+	IP    string `json:"ip"`
+	Mask  int64  `json:"mask"`
+	Port  int64  `json:"port"`
+	Tag   string `json:"tag"`
+}
+
+type PermissionsDomain struct {
+	Group  int64  `json:"group"`
+	Domain string `json:"domain_name"`
+	Port   int64  `json:"port"`
+	Tag    string `json:"tag"`
+}
+
+type PermissionsTrusted struct {
+	Table       int64  `json:"table"`
+	IP          string `json:"ip"`
+	Proto       string `json:"proto"`
+	Pattern     string `json:"pattern"`
+	RURIPattern string `json:"ruri_pattern"`
+	Tag         string `json:"tag"`
+	Priority    int64  `json:"priority"`
+}
+
+// PermissionsCheckResult reports whether an address is allowed in a group
+// and which address or subnet entry matched
+type PermissionsCheckResult struct {
+	Allowed bool                `json:"allowed"`
+	Address *PermissionsAddress `json:"address,omitempty"`
+	Subnet  *PermissionsSubnet  `json:"subnet,omitempty"`
+}
+
+func (a *API) PermissionsAddressDump(ctx context.Context) ([]PermissionsAddress, error) {
+	a.logger.Debug("permissions address dump")
+	x := rpcList[PermissionsAddress]{}
+	if err := a.call(ctx, "permissions.addressDump", nil, &x); err != nil {
+		return []PermissionsAddress{}, err
+	}
+	return x, nil
+}
+
+func (a *API) PermissionsSubnetDump(ctx context.Context) ([]PermissionsSubnet, error) {
+	a.logger.Debug("permissions subnet dump")
+	x := rpcList[PermissionsSubnet]{}
+	if err := a.call(ctx, "permissions.subnetDump", nil, &x); err != nil {
+		return []PermissionsSubnet{}, err
+	}
+	return x, nil
+}
+
+func (a *API) PermissionsDomainDump(ctx context.Context) ([]PermissionsDomain, error) {
+	a.logger.Debug("permissions domain dump")
+	x := rpcList[PermissionsDomain]{}
+	if err := a.call(ctx, "permissions.domainDump", nil, &x); err != nil {
+		return []PermissionsDomain{}, err
+	}
+	return x, nil
+}
+
+func (a *API) PermissionsTrustedDump(ctx context.Context) ([]PermissionsTrusted, error) {
+	a.logger.Debug("permissions trusted dump")
+	x := rpcList[PermissionsTrusted]{}
+	if err := a.call(ctx, "permissions.trustedDump", nil, &x); err != nil {
+		return []PermissionsTrusted{}, err
+	}
+	return x, nil
+}
+
+func (a *API) PermissionsAddressReload(ctx context.Context) error {
+	a.logger.Debug("permissions address reload")
+	return a.call(ctx, "permissions.addressReload", nil, nil)
+}
+
+func (a *API) PermissionsTrustedReload(ctx context.Context) error {
+	a.logger.Debug("permissions trusted reload")
+	return a.call(ctx, "permissions.trustedReload", nil, nil)
+}
+
+// PermissionsTestURI checks uri and contact against the allow/deny files
+// loaded under basename and returns kamailio's verdict
+func (a *API) PermissionsTestURI(ctx context.Context, basename string, uri string, contact string) (string, error) {
+	a.logger.Debug("permissions test uri", zap.String("basename", basename), zap.String("uri", uri), zap.String("contact", contact))
+	x := ""
+	err := a.call(ctx, "permissions.testUri", []interface{}{basename, uri, contact}, &x)
+	return x, err
+}
+
+// PermissionsCheckAddress reports whether ip and port match an address or
+// subnet entry of group, the same lookup allow_address performs. A port of
+// 0 in an entry matches any port.
+func (a *API) PermissionsCheckAddress(ctx context.Context, group int64, ip string, port int64) (PermissionsCheckResult, error) {
+	a.logger.Debug("permissions check address", zap.Int64("group", group), zap.String("ip", ip), zap.Int64("port", port))
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return PermissionsCheckResult{}, fmt.Errorf("invalid ip [%s]", ip)
+	}
+	addresses, err := a.PermissionsAddressDump(ctx)
+	if err != nil {
+		return PermissionsCheckResult{}, err
+	}
+	for _, v := range addresses {
+		if v.Group != group || (v.Port != 0 && v.Port != port) {
+			continue
+		}
+		if x := net.ParseIP(v.IP); x != nil && x.Equal(addr) {
+			return PermissionsCheckResult{Allowed: true, Address: &v}, nil
+		}
+	}
+	subnets, err := a.PermissionsSubnetDump(ctx)
+	if err != nil {
+		return PermissionsCheckResult{}, err
+	}
+	for _, v := range subnets {
+		if v.Group != group || (v.Port != 0 && v.Port != port) {
+			continue
+		}
+		_, n, err := net.ParseCIDR(fmt.Sprintf("%s/%d", v.IP, v.Mask))
+		if err != nil {
+			continue
+		}
+		if n.Contains(addr) {
+			return PermissionsCheckResult{Allowed: true, Subnet: &v}, nil
+		}
+	}
+	return PermissionsCheckResult{Allowed: false}, nil
+}
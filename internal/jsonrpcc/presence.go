@@ -0,0 +1,162 @@
+package jsonrpcc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// presence.refreshWatchers types
+const (
+	// PresenceRefreshPresentity notifies watchers of a presentity change
+	PresenceRefreshPresentity = 0
+	// PresenceRefreshAuthRules re-evaluates watcher authorization rules
+	PresenceRefreshAuthRules = 1
+)
+
+type Presentity struct {
+	User         string `json:"user"`
+	Domain       string `json:"domain"`
+	Event        string `json:"event"`
+	ETag         string `json:"etag"`
+	Sender       string `json:"sender"`
+	Body         string `json:"body"`
+	Priority     int64  `json:"priority"`
+	ReceivedTime int64  `json:"received_time"`
+	Expires      int64  `json:"expires"`
+}
+
+type PresenceWatcher struct {
+	PresentityURI   string `json:"presentity_uri"`
+	WatcherUsername string `json:"watcher_username"`
+	WatcherDomain   string `json:"watcher_domain"`
+	ToUser          string `json:"to_user"`
+	ToDomain        string `json:"to_domain"`
+	Event           string `json:"event"`
+	EventID         string `json:"event_id"`
+	CallID          string `json:"callid"`
+	Contact         string `json:"contact"`
+	Status          int64  `json:"status"`
+	Reason          string `json:"reason"`
+	Expires         int64  `json:"expires"`
+}
+
+// PUAPublish is a PUBLISH sent by the pua module
+type PUAPublish struct {
+	PresentityURI string `json:"presentity_uri"`
+	Expires       int    `json:"expires"`
+	Event         string `json:"event"`
+	ContentType   string `json:"content_type"`
+	// ID identifies the publication in pua, ETag refreshes an existing one
+	ID            string `json:"id"`
+	ETag          string `json:"etag"`
+	OutboundProxy string `json:"outbound_proxy"`
+	// ExtraHeaders are added to the request
+	ExtraHeaders map[string]string `json:"extra_headers"`
+	Body         string            `json:"body"`
+}
+
+type PUAPublishResult struct {
+	Code    int    `json:"code"`
+	Reason  string `json:"reason"`
+	ETag    string `json:"etag,omitempty"`
+	Expires int64  `json:"expires,omitempty"`
+}
+
+// PresenceCleanup removes expired presentities and watchers
+func (a *API) PresenceCleanup(ctx context.Context) error {
+	a.logger.Debug("presence cleanup")
+	return a.call(ctx, "presence.cleanup", nil, nil)
+}
+
+// PresenceRefreshWatchers notifies the watchers of uri for event, refreshType
+// is PresenceRefreshPresentity or PresenceRefreshAuthRules
+func (a *API) PresenceRefreshWatchers(ctx context.Context, uri, event string, refreshType int) error {
+	a.logger.Debug("presence refresh watchers", zap.String("uri", uri), zap.String("event", event), zap.Int("type", refreshType))
+	return a.call(ctx, "presence.refreshWatchers", []interface{}{uri, event, refreshType}, nil)
+}
+
+// PresencePresentityList returns every presentity in the presence cache
+func (a *API) PresencePresentityList(ctx context.Context) ([]Presentity, error) {
+	a.logger.Debug("presence presentity list")
+	x := rpcList[Presentity]{}
+	if err := a.call(ctx, "presence.presentity_list", []interface{}{"full"}, &x); err != nil {
+		return []Presentity{}, err
+	}
+	return x, nil
+}
+
+// PresencePresentityShow returns the presentities published for uri
+func (a *API) PresencePresentityShow(ctx context.Context, uri string) ([]Presentity, error) {
+	a.logger.Debug("presence presentity show", zap.String("uri", uri))
+	x := rpcList[Presentity]{}
+	if err := a.call(ctx, "presence.presentity_show", []interface{}{uri, "full"}, &x); err != nil {
+		return []Presentity{}, err
+	}
+	return x, nil
+}
+
+// PresenceWatcherList returns the watchers subscribed to uri for event
+func (a *API) PresenceWatcherList(ctx context.Context, uri, event string) ([]PresenceWatcher, error) {
+	a.logger.Debug("presence watcher list", zap.String("uri", uri), zap.String("event", event))
+	x := rpcList[PresenceWatcher]{}
+	if err := a.call(ctx, "presence.watcher_list", []interface{}{uri, event}, &x); err != nil {
+		return []PresenceWatcher{}, err
+	}
+	return x, nil
+}
+
+// Validate rejects values that would end a line of the PUBLISH pua builds, so
+// callers cannot inject headers or a body
+func (x PUAPublish) Validate() error {
+	for k, v := range map[string]string{"presentity_uri": x.PresentityURI, "event": x.Event, "content_type": x.ContentType, "id": x.ID, "etag": x.ETag, "outbound_proxy": x.OutboundProxy} {
+		if strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("%s must not contain CR or LF", k)
+		}
+	}
+	return validateHeaders(x.ExtraHeaders)
+}
+
+func (a *API) PUAPublish(ctx context.Context, x PUAPublish) (PUAPublishResult, error) {
+	a.logger.Debug("pua publish", zap.String("uri", x.PresentityURI), zap.String("event", x.Event))
+	if err := x.Validate(); err != nil {
+		return PUAPublishResult{}, err
+	}
+	params := []interface{}{
+		x.PresentityURI,
+		x.Expires,
+		x.Event,
+		dotIfEmpty(x.ContentType),
+		dotIfEmpty(x.ID),
+		dotIfEmpty(x.ETag),
+		dotIfEmpty(x.OutboundProxy),
+		dotIfEmpty(headerBlock(x.ExtraHeaders)),
+		dotIfEmpty(x.Body),
+	}
+	z := []json.RawMessage{}
+	if err := a.call(ctx, "pua.publish", params, &z); err != nil {
+		return PUAPublishResult{}, err
+	}
+	// code, reason and, on success, the etag and expires of the publication
+	r := PUAPublishResult{}
+	for i, v := range z {
+		var err error
+		switch i {
+		case 0:
+			err = json.Unmarshal(v, &r.Code)
+		case 1:
+			err = json.Unmarshal(v, &r.Reason)
+		case 2:
+			err = json.Unmarshal(v, &r.ETag)
+		case 3:
+			err = json.Unmarshal(v, &r.Expires)
+		}
+		if err != nil {
+			return PUAPublishResult{}, fmt.Errorf("unexpected pua.publish value [%s]", string(v))
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,64 @@
+package jsonrpcc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// RTPEngineAll selects every rtpengine node in show, enable and ping
+const RTPEngineAll = "all"
+
+type RTPEngineNode struct {
+	URL          string `json:"url"`
+	Set          int64  `json:"set"`
+	Index        int64  `json:"index"`
+	Weight       int64  `json:"weight"`
+	Disabled     int64  `json:"disabled"`
+	RecheckTicks int64  `json:"recheck_ticks"`
+}
+
+// RTPEngineShow returns node url, or every node when url is RTPEngineAll
+func (a *API) RTPEngineShow(ctx context.Context, url string) ([]RTPEngineNode, error) {
+	a.logger.Debug("rtpengine show", zap.String("url", url))
+	x := rpcList[RTPEngineNode]{}
+	if err := a.call(ctx, "rtpengine.show", []interface{}{url}, &x); err != nil {
+		return []RTPEngineNode{}, err
+	}
+	return x, nil
+}
+
+// RTPEngineEnable enables or disables node url, or every node when url is RTPEngineAll
+func (a *API) RTPEngineEnable(ctx context.Context, url string, enabled bool) error {
+	a.logger.Debug("rtpengine enable", zap.String("url", url), zap.Bool("enabled", enabled))
+	flag := 0
+	if enabled {
+		flag = 1
+	}
+	return a.call(ctx, "rtpengine.enable", []interface{}{url, flag}, nil)
+}
+
+// RTPEnginePing pings node url, or every node when url is RTPEngineAll, and
+// returns the nodes with their state afterwards
+func (a *API) RTPEnginePing(ctx context.Context, url string) ([]RTPEngineNode, error) {
+	a.logger.Debug("rtpengine ping", zap.String("url", url))
+	x := rpcList[RTPEngineNode]{}
+	if err := a.call(ctx, "rtpengine.ping", []interface{}{url}, &x); err != nil {
+		return []RTPEngineNode{}, err
+	}
+	return x, nil
+}
+
+// RTPEngineReload reloads the nodes from the rtpengine database table
+func (a *API) RTPEngineReload(ctx context.Context) error {
+	a.logger.Debug("rtpengine reload")
+	return a.call(ctx, "rtpengine.reload", nil, nil)
+}
+
+// RTPEngineHashTotal returns the number of calls in the rtpengine hash table
+func (a *API) RTPEngineHashTotal(ctx context.Context) (int64, error) {
+	a.logger.Debug("rtpengine get hash total")
+	var x int64
+	err := a.call(ctx, "rtpengine.get_hash_total", nil, &x)
+	return x, err
+}
@@ -0,0 +1,64 @@
+package jsonrpcc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SCASubscription is a line printed by the sca subscription rpcs, the
+// leading fields are parsed and the full line is kept in Line
+type SCASubscription struct {
+	AOR        string `json:"aor"`
+	Event      string `json:"event"`
+	Subscriber string `json:"subscriber"`
+	State      string `json:"state"`
+	Expires    int64  `json:"expires"`
+	Line       string `json:"line"`
+}
+
+func (a *API) SCAAllSubscriptions(ctx context.Context) ([]SCASubscription, error) {
+	a.logger.Debug("sca all subscriptions")
+	x := rpcList[string]{}
+	if err := a.call(ctx, "sca.all_subscriptions", nil, &x); err != nil {
+		return []SCASubscription{}, err
+	}
+	return parseSCASubscriptions(x), nil
+}
+
+// SCAShowSubscription returns the subscriptions to aor for event, call-info
+// or line-seize
+func (a *API) SCAShowSubscription(ctx context.Context, event, aor string) ([]SCASubscription, error) {
+	a.logger.Debug("sca show subscription", zap.String("event", event), zap.String("aor", aor))
+	x := rpcList[string]{}
+	if err := a.call(ctx, "sca.show_subscription", []interface{}{event, aor}, &x); err != nil {
+		return []SCASubscription{}, err
+	}
+	return parseSCASubscriptions(x), nil
+}
+
+func parseSCASubscriptions(x []string) []SCASubscription {
+	subs := []SCASubscription{}
+	for _, v := range x {
+		for _, l := range strings.Split(v, "\n") {
+			l = strings.TrimSpace(l)
+			if l == "" {
+				continue
+			}
+			s := SCASubscription{Line: l}
+			f := strings.Fields(l)
+			for i, p := range []*string{&s.AOR, &s.Event, &s.Subscriber, &s.State} {
+				if i < len(f) {
+					*p = f[i]
+				}
+			}
+			if len(f) > 4 {
+				s.Expires, _ = strconv.ParseInt(f[4], 10, 64)
+			}
+			subs = append(subs, s)
+		}
+	}
+	return subs
+}
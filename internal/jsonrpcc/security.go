@@ -0,0 +1,101 @@
+package jsonrpcc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SecfilterTypes are the list types accepted by secfilter.add_* and secfilter.del_*
+var SecfilterTypes = []string{"ua", "country", "domain", "ip", "user"}
+
+type PikeEntry struct {
+	IPAddr       string `json:"ip_addr"`
+	LeafHitsPrev int64  `json:"leaf_hits_prev"`
+	LeafHitsCurr int64  `json:"leaf_hits_curr"`
+	Expires      int64  `json:"expires"`
+	Status       string `json:"status"`
+}
+
+// PikeTop returns the addresses tracked by pike. filter is ALL, HOT or WARM
+// and defaults to HOT when empty.
+func (a *API) PikeTop(ctx context.Context, filter string) ([]PikeEntry, error) {
+	a.logger.Debug("pike top", zap.String("filter", filter))
+	var params []interface{}
+	if filter != "" {
+		params = []interface{}{strings.ToUpper(filter)}
+	}
+	x := rpcList[PikeEntry]{}
+	if err := a.call(ctx, "pike.top", params, &x); err != nil {
+		return []PikeEntry{}, err
+	}
+	return x, nil
+}
+
+func secfilterType(t string) error {
+	for _, v := range SecfilterTypes {
+		if v == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown secfilter type [%s], expected one of %s", t, strings.Join(SecfilterTypes, ", "))
+}
+
+func (a *API) SecfilterAddBlacklist(ctx context.Context, listType string, value string) error {
+	a.logger.Debug("secfilter add blacklist", zap.String("type", listType), zap.String("value", value))
+	if err := secfilterType(listType); err != nil {
+		return err
+	}
+	return a.call(ctx, "secfilter.add_bl", []interface{}{listType, value}, nil)
+}
+
+func (a *API) SecfilterDelBlacklist(ctx context.Context, listType string, value string) error {
+	a.logger.Debug("secfilter del blacklist", zap.String("type", listType), zap.String("value", value))
+	if err := secfilterType(listType); err != nil {
+		return err
+	}
+	return a.call(ctx, "secfilter.del_bl", []interface{}{listType, value}, nil)
+}
+
+func (a *API) SecfilterAddWhitelist(ctx context.Context, listType string, value string) error {
+	a.logger.Debug("secfilter add whitelist", zap.String("type", listType), zap.String("value", value))
+	if err := secfilterType(listType); err != nil {
+		return err
+	}
+	return a.call(ctx, "secfilter.add_wl", []interface{}{listType, value}, nil)
+}
+
+func (a *API) SecfilterDelWhitelist(ctx context.Context, listType string, value string) error {
+	a.logger.Debug("secfilter del whitelist", zap.String("type", listType), zap.String("value", value))
+	if err := secfilterType(listType); err != nil {
+		return err
+	}
+	return a.call(ctx, "secfilter.del_wl", []interface{}{listType, value}, nil)
+}
+
+// SecfilterPrint returns the loaded lists as printed by secfilter.print, one
+// line per entry
+func (a *API) SecfilterPrint(ctx context.Context) ([]string, error) {
+	a.logger.Debug("secfilter print")
+	x := rpcList[string]{}
+	if err := a.call(ctx, "secfilter.print", nil, &x); err != nil {
+		return []string{}, err
+	}
+	lines := []string{}
+	for _, v := range x {
+		for _, l := range strings.Split(v, "\n") {
+			if strings.TrimSpace(l) == "" {
+				continue
+			}
+			lines = append(lines, l)
+		}
+	}
+	return lines, nil
+}
+
+func (a *API) SecfilterReload(ctx context.Context) error {
+	a.logger.Debug("secfilter reload")
+	return a.call(ctx, "secfilter.reload", nil, nil)
+}
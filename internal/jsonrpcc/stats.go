@@ -0,0 +1,43 @@
+package jsonrpcc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// StatsGet returns the statistics matching names, a name is a single
+// statistic such as tmx:active_transactions or a group such as topos:
+func (a *API) StatsGet(ctx context.Context, names ...string) (map[string]int64, error) {
+	a.logger.Debug("stats get statistics", zap.Strings("names", names))
+	params := make([]interface{}, 0, len(names))
+	for _, n := range names {
+		params = append(params, n)
+	}
+	x := rpcList[string]{}
+	if err := a.call(ctx, "stats.get_statistics", params, &x); err != nil {
+		return map[string]int64{}, err
+	}
+	// each line is "group:name = value"
+	z := map[string]int64{}
+	for _, l := range x {
+		k, v, ok := strings.Cut(l, "=")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			continue
+		}
+		z[strings.TrimSpace(k)] = n
+	}
+	return z, nil
+}
+
+// ToposStats returns the topos statistics group, topos has no rpc commands of
+// its own
+func (a *API) ToposStats(ctx context.Context) (map[string]int64, error) {
+	return a.StatsGet(ctx, "topos:")
+}
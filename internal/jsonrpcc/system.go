@@ -0,0 +1,96 @@
+package jsonrpcc
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type RPCMethod struct {
+	Name string `json:"name"`
+	Help string `json:"help,omitempty"`
+}
+
+// rpcMethods caches the methods kamailio reported on the last refresh
+type rpcMethods struct {
+	mu       sync.RWMutex
+	methods  []RPCMethod
+	loadedAt time.Time
+}
+
+// SystemListMethods returns the rpc methods exported by the loaded modules
+func (a *API) SystemListMethods(ctx context.Context) ([]string, error) {
+	a.logger.Debug("system list methods")
+	x := rpcList[string]{}
+	if err := a.call(ctx, "system.listMethods", nil, &x); err != nil {
+		return []string{}, err
+	}
+	return x, nil
+}
+
+func (a *API) SystemMethodHelp(ctx context.Context, method string) (string, error) {
+	a.logger.Debug("system method help", zap.String("method", method))
+	var x string
+	err := a.call(ctx, "system.methodHelp", []interface{}{method}, &x)
+	return x, err
+}
+
+// RefreshMethods reloads the method list and help text from kamailio
+func (a *API) RefreshMethods(ctx context.Context) ([]RPCMethod, error) {
+	names, err := a.SystemListMethods(ctx)
+	if err != nil {
+		return []RPCMethod{}, err
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+	calls := make([]Call, 0, len(names))
+	for _, n := range names {
+		calls = append(calls, Call{Method: "system.methodHelp", Params: []interface{}{n}})
+	}
+	help, err := a.Batch(ctx, calls...)
+	if err != nil {
+		return []RPCMethod{}, err
+	}
+	x := make([]RPCMethod, 0, len(names))
+	for i, n := range names {
+		m := RPCMethod{Name: n}
+		if err := help[i].Decode(&m.Help); err != nil {
+			// keep the method, only the help text is missing
+			a.logger.Debug("could not get method help", zap.String("method", n), zap.Error(err))
+		}
+		x = append(x, m)
+	}
+	a.methods.mu.Lock()
+	a.methods.methods = x
+	a.methods.loadedAt = time.Now()
+	a.methods.mu.Unlock()
+	return x, nil
+}
+
+// Methods returns the cached methods and when they were loaded, a zero time
+// means no refresh has succeeded yet
+func (a *API) Methods() ([]RPCMethod, time.Time) {
+	a.methods.mu.RLock()
+	defer a.methods.mu.RUnlock()
+	return a.methods.methods, a.methods.loadedAt
+}
+
+// HasMethodPrefix reports whether kamailio exports a method starting with
+// prefix, such as "tm.". known is false until a refresh succeeds.
+func (a *API) HasMethodPrefix(prefix string) (found bool, known bool) {
+	a.methods.mu.RLock()
+	defer a.methods.mu.RUnlock()
+	if a.methods.loadedAt.IsZero() {
+		return false, false
+	}
+	for _, m := range a.methods.methods {
+		if strings.HasPrefix(m.Name, prefix) {
+			return true, true
+		}
+	}
+	return false, true
+}
@@ -0,0 +1,59 @@
+package jsonrpcc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type TLSConnection struct {
+	ID       int64  `json:"id"`
+	Timeout  int64  `json:"timeout"`
+	SrcIP    string `json:"src_ip"`
+	SrcPort  int64  `json:"src_port"`
+	DstIP    string `json:"dst_ip"`
+	DstPort  int64  `json:"dst_port"`
+	Cipher   string `json:"cipher"`
+	CtWqSize int64  `json:"ct_wq_size"`
+	EncRdBuf int64  `json:"enc_rd_buf"`
+	Flags    int64  `json:"flags"`
+	State    string `json:"state"`
+}
+
+type TLSInfo struct {
+	MaxConnections            int64 `json:"max_connections"`
+	OpenedConnections         int64 `json:"opened_connections"`
+	ClearTextWriteQueuedBytes int64 `json:"clear_text_write_queued_bytes"`
+}
+
+// TLSConnections returns the open tls connections with their cipher. tls.list
+// does not report the peer certificate subject.
+func (a *API) TLSConnections(ctx context.Context) ([]TLSConnection, error) {
+	a.logger.Debug("tls list")
+	x := rpcList[TLSConnection]{}
+	if err := a.call(ctx, "tls.list", nil, &x); err != nil {
+		return []TLSConnection{}, err
+	}
+	return x, nil
+}
+
+func (a *API) TLSInfo(ctx context.Context) (TLSInfo, error) {
+	a.logger.Debug("tls info")
+	x := TLSInfo{}
+	err := a.call(ctx, "tls.info", nil, &x)
+	return x, err
+}
+
+// TLSOptions returns the tls module parameters, kept as returned since the
+// set differs between kamailio versions
+func (a *API) TLSOptions(ctx context.Context) (map[string]json.RawMessage, error) {
+	a.logger.Debug("tls options")
+	x := map[string]json.RawMessage{}
+	err := a.call(ctx, "tls.options", nil, &x)
+	return x, err
+}
+
+// TLSReload reloads the tls config file, certificates and keys
+func (a *API) TLSReload(ctx context.Context) error {
+	a.logger.Debug("tls reload")
+	return a.call(ctx, "tls.reload", nil, nil)
+}
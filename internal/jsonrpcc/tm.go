@@ -0,0 +1,107 @@
+package jsonrpcc
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type TMStats struct {
+	Current      int64 `json:"current"`
+	Waiting      int64 `json:"waiting"`
+	Total        int64 `json:"total"`
+	TotalLocal   int64 `json:"total_local"`
+	RplReceived  int64 `json:"rpl_received"`
+	RplGenerated int64 `json:"rpl_generated"`
+	RplSent      int64 `json:"rpl_sent"`
+	Status6xx    int64 `json:"6xx"`
+	Status5xx    int64 `json:"5xx"`
+	Status4xx    int64 `json:"4xx"`
+	Status3xx    int64 `json:"3xx"`
+	Status2xx    int64 `json:"2xx"`
+	Created      int64 `json:"created"`
+	Freed        int64 `json:"freed"`
+	DelayedFree  int64 `json:"delayed_free"`
+}
+
+type TMTransaction struct {
+	Cell       string `json:"cell"`
+	TIndex     int64  `json:"tindex"`
+	TLabel     int64  `json:"tlabel"`
+	Method     string `json:"method"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	CallID     string `json:"callid"`
+	CSeq       string `json:"cseq"`
+	UASRequest string `json:"uas_request"`
+	TFlags     int64  `json:"tflags"`
+	Outgoing   int64  `json:"outgoing"`
+	RefCount   int64  `json:"ref_count"`
+	Lifetime   int64  `json:"lifetime"`
+}
+
+// TransID returns the hash:label id used by tm.reply
+func (t TMTransaction) TransID() string {
+	return fmt.Sprintf("%d:%d", t.TIndex, t.TLabel)
+}
+
+type TMHashStats struct {
+	HashSize           int64   `json:"hash_size"`
+	CrtTransactions    int64   `json:"crt_transactions"`
+	AvgHashBucketLoad  float64 `json:"avg_hash_bucket_load"`
+	Transactions       int64   `json:"transactions"`
+	AvgTransactionLoad float64 `json:"avg_transaction_load,omitempty"`
+}
+
+// TMReply is a final reply sent on an existing transaction by tm.reply
+type TMReply struct {
+	Code   int    `json:"code"`
+	Reason string `json:"reason"`
+	// TransID is the transaction hash:label as returned by TMTransaction.TransID
+	TransID string `json:"trans_id"`
+	ToTag   string `json:"to_tag"`
+	// Headers are extra headers, each terminated by CRLF
+	Headers string `json:"headers"`
+	Body    string `json:"body"`
+}
+
+func (a *API) TMStats(ctx context.Context) (TMStats, error) {
+	a.logger.Debug("tm stats")
+	x := TMStats{}
+	err := a.call(ctx, "tm.stats", nil, &x)
+	return x, err
+}
+
+// TMList returns the transactions currently in the tm hash table
+func (a *API) TMList(ctx context.Context) ([]TMTransaction, error) {
+	a.logger.Debug("tm list")
+	x := rpcList[TMTransaction]{}
+	if err := a.call(ctx, "tm.list", nil, &x); err != nil {
+		return []TMTransaction{}, err
+	}
+	return x, nil
+}
+
+// TMCancel cancels the pending transaction matching callid and the cseq
+// number
+func (a *API) TMCancel(ctx context.Context, callid, cseq string) error {
+	a.logger.Debug("tm cancel", zap.String("callid", callid), zap.String("cseq", cseq))
+	return a.call(ctx, "tm.cancel", []interface{}{callid, cseq}, nil)
+}
+
+func (a *API) TMReply(ctx context.Context, x TMReply) error {
+	a.logger.Debug("tm reply", zap.String("trans_id", x.TransID), zap.Int("code", x.Code))
+	params := []interface{}{x.Code, x.Reason, x.TransID, x.ToTag, x.Headers}
+	if x.Body != "" {
+		params = append(params, x.Body)
+	}
+	return a.call(ctx, "tm.reply", params, nil)
+}
+
+func (a *API) TMHashStats(ctx context.Context) (TMHashStats, error) {
+	a.logger.Debug("tm hash stats")
+	x := TMHashStats{}
+	err := a.call(ctx, "tm.hash_stats", nil, &x)
+	return x, err
+}
@@ -0,0 +1,203 @@
+package jsonrpcc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SIPRequest is a request sent by kamailio as a local UAC
+type SIPRequest struct {
+	Method string `json:"method"`
+	RURI   string `json:"ruri"`
+	// Headers are added to the request, From and To default to the RURI host
+	// and RURI when missing. Call-ID, CSeq and Via are set by kamailio.
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+	// OutboundProxy is the next hop uri, empty routes on the RURI
+	OutboundProxy string `json:"outbound_proxy"`
+	// Socket is the local socket to send from such as udp:10.0.0.1:5060,
+	// empty lets kamailio pick one
+	Socket string `json:"socket"`
+}
+
+// SIPReply is the final reply to a SIPRequest
+type SIPReply struct {
+	Code    int                 `json:"code"`
+	Reason  string              `json:"reason"`
+	Headers map[string][]string `json:"headers"`
+	Body    string              `json:"body,omitempty"`
+}
+
+// SIPSend starts the request and returns without waiting for a reply
+func (a *API) SIPSend(ctx context.Context, x SIPRequest) error {
+	a.logger.Debug("tm t_uac_start", zap.String("method", x.Method), zap.String("ruri", x.RURI))
+	params, err := uacParams(x)
+	if err != nil {
+		return err
+	}
+	return a.call(ctx, "tm.t_uac_start", params, nil)
+}
+
+// SIPSendWait sends the request and waits for its final reply
+func (a *API) SIPSendWait(ctx context.Context, x SIPRequest) (SIPReply, error) {
+	a.logger.Debug("tm t_uac_wait", zap.String("method", x.Method), zap.String("ruri", x.RURI))
+	params, err := uacParams(x)
+	if err != nil {
+		return SIPReply{}, err
+	}
+	z := json.RawMessage{}
+	if err := a.call(ctx, "tm.t_uac_wait", params, &z); err != nil {
+		return SIPReply{}, err
+	}
+	return parseUACReply(z)
+}
+
+// Validate rejects values that would end a line of the request kamailio
+// builds, so callers cannot inject headers or a body
+func (x SIPRequest) Validate() error {
+	for k, v := range map[string]string{"method": x.Method, "ruri": x.RURI, "outbound_proxy": x.OutboundProxy, "socket": x.Socket} {
+		if strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("%s must not contain CR or LF", k)
+		}
+	}
+	return validateHeaders(x.Headers)
+}
+
+// validateHeaders rejects empty or malformed names and CR or LF in names and values
+func validateHeaders(h map[string]string) error {
+	for k, v := range h {
+		if k == "" || strings.ContainsAny(k, ":\r\n") {
+			return fmt.Errorf("invalid header name [%q]", k)
+		}
+		if strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("header %s must not contain CR or LF", k)
+		}
+	}
+	return nil
+}
+
+// uacParams returns method, ruri, next hop, socket, headers and body as
+// expected by tm.t_uac_*, "." marks an empty value
+func uacParams(x SIPRequest) ([]interface{}, error) {
+	if err := x.Validate(); err != nil {
+		return nil, err
+	}
+	h := map[string]string{}
+	for k, v := range x.Headers {
+		h[k] = v
+	}
+	if !hasHeader(h, "To", "t") {
+		h["To"] = "<" + x.RURI + ">"
+	}
+	if !hasHeader(h, "From", "f") {
+		h["From"] = "<sip:kamailio-jsonrpc-client@" + sipHost(x.RURI) + ">"
+	}
+	params := []interface{}{x.Method, x.RURI, dotIfEmpty(x.OutboundProxy), dotIfEmpty(x.Socket), headerBlock(h)}
+	if x.Body != "" {
+		params = append(params, x.Body)
+	}
+	return params, nil
+}
+
+// headerBlock returns the headers sorted by name, each terminated by CRLF
+func headerBlock(h map[string]string) string {
+	names := make([]string, 0, len(h))
+	for k := range h {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+	var b strings.Builder
+	for _, k := range names {
+		fmt.Fprintf(&b, "%s: %s\r\n", k, h[k])
+	}
+	return b.String()
+}
+
+func hasHeader(h map[string]string, names ...string) bool {
+	for k := range h {
+		for _, n := range names {
+			if strings.EqualFold(k, n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sipHost returns the host part of a sip uri
+func sipHost(uri string) string {
+	_, s, ok := strings.Cut(uri, ":")
+	if !ok {
+		s = uri
+	}
+	if _, host, ok := strings.Cut(s, "@"); ok {
+		s = host
+	}
+	if i := strings.IndexAny(s, ";?>"); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+func dotIfEmpty(s string) string {
+	if s == "" {
+		return "."
+	}
+	return s
+}
+
+// parseUACReply decodes the tm.t_uac_wait result, which is the reply code,
+// the reason and the raw header block followed by the body
+func parseUACReply(b json.RawMessage) (SIPReply, error) {
+	items := []json.RawMessage{}
+	if err := json.Unmarshal(b, &items); err != nil {
+		items = []json.RawMessage{b}
+	}
+	x := SIPReply{Headers: map[string][]string{}}
+	rest := []string{}
+	for _, v := range items {
+		var code int
+		if err := json.Unmarshal(v, &code); err == nil && x.Code == 0 {
+			x.Code = code
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			return SIPReply{}, fmt.Errorf("unexpected t_uac_wait value [%s]", string(v))
+		}
+		if x.Code == 0 {
+			// "200 OK" as a single status line
+			c, reason, _ := strings.Cut(s, " ")
+			if n, err := strconv.Atoi(c); err == nil {
+				x.Code = n
+				s = reason
+			}
+		}
+		if x.Reason == "" && !strings.Contains(s, "\n") {
+			x.Reason = s
+			continue
+		}
+		rest = append(rest, s)
+	}
+	if x.Code == 0 {
+		return SIPReply{}, fmt.Errorf("missing reply code in [%s]", string(b))
+	}
+	raw := strings.ReplaceAll(strings.Join(rest, ""), "\r\n", "\n")
+	hdrs, body, _ := strings.Cut(raw, "\n\n")
+	for _, l := range strings.Split(hdrs, "\n") {
+		k, v, ok := strings.Cut(l, ":")
+		if !ok {
+			continue
+		}
+		k = strings.TrimSpace(k)
+		x.Headers[k] = append(x.Headers[k], strings.TrimSpace(v))
+	}
+	x.Body = body
+	return x, nil
+}
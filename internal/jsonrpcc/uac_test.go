@@ -0,0 +1,91 @@
+package jsonrpcc
+
+import "testing"
+
+func TestUACParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		x       SIPRequest
+		want    []interface{}
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			x:    SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com"},
+			want: []interface{}{"OPTIONS", "sip:100@example.com", ".", ".", "From: <sip:kamailio-jsonrpc-client@example.com>\r\nTo: <sip:100@example.com>\r\n"},
+		},
+		{
+			name: "headers and body",
+			x: SIPRequest{
+				Method:        "NOTIFY",
+				RURI:          "sip:100@10.0.0.2:5060",
+				Headers:       map[string]string{"Event": "check-sync", "f": "<sip:pbx@example.com>"},
+				Body:          "a\r\nb",
+				OutboundProxy: "sip:10.0.0.2",
+				Socket:        "udp:10.0.0.1:5060",
+			},
+			want: []interface{}{"NOTIFY", "sip:100@10.0.0.2:5060", "sip:10.0.0.2", "udp:10.0.0.1:5060", "Event: check-sync\r\nTo: <sip:100@10.0.0.2:5060>\r\nf: <sip:pbx@example.com>\r\n", "a\r\nb"},
+		},
+		{
+			name:    "header value injection",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com", Headers: map[string]string{"Subject": "x\r\nX-Evil: 1"}},
+			wantErr: true,
+		},
+		{
+			name:    "header value body injection",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com", Headers: map[string]string{"Subject": "x\r\n\r\nbody"}},
+			wantErr: true,
+		},
+		{
+			name:    "bare lf in value",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com", Headers: map[string]string{"Subject": "x\nX-Evil: 1"}},
+			wantErr: true,
+		},
+		{
+			name:    "header name injection",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com", Headers: map[string]string{"X-Evil: 1\r\nSubject": "x"}},
+			wantErr: true,
+		},
+		{
+			name:    "colon in header name",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com", Headers: map[string]string{"X-A:B": "x"}},
+			wantErr: true,
+		},
+		{
+			name:    "empty header name",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com", Headers: map[string]string{"": "x"}},
+			wantErr: true,
+		},
+		{
+			name:    "ruri injection",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com\r\nX-Evil: 1"},
+			wantErr: true,
+		},
+		{
+			name:    "outbound proxy injection",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com", OutboundProxy: "sip:10.0.0.2\n"},
+			wantErr: true,
+		},
+		{
+			name:    "socket injection",
+			x:       SIPRequest{Method: "OPTIONS", RURI: "sip:100@example.com", Socket: "udp:10.0.0.1:5060\r"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uacParams(tt.x)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("uacParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("uacParams() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("uacParams()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
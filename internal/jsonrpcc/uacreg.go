@@ -49,17 +49,16 @@ func (a *API) uaclist(ctx context.Context) ([]User, error) {
 	if err != nil {
 		return x, err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return x, err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return x, err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		c, err := io.ReadAll(res.Body)
 		if err != nil {
@@ -130,17 +129,16 @@ func (a *API) uacRemove(ctx context.Context, id string) error {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("unexpected status code", zap.Int("status code", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
@@ -200,17 +198,16 @@ func (a *API) uacAdd(ctx context.Context, id string, username string, domain str
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.jsonrpcHTTPAddr, bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
-	req.Close = true
 	req.Header.Set("Content-Type", "application/json")
 	res, err := a.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
+	defer closeBody(res.Body)
 	if res.StatusCode != http.StatusOK {
 		a.logger.Debug("unexpected status code", zap.Int("status code", res.StatusCode))
 		x, err := io.ReadAll(res.Body)
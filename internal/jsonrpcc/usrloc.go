@@ -0,0 +1,77 @@
+package jsonrpcc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// ErrAORNotFound is returned by UsrlocLookup for an aor with no record
+var ErrAORNotFound = errors.New("aor not found")
+
+// usrlocNotSet is printed by ul.lookup for empty contact fields
+const usrlocNotSet = "[not set]"
+
+type UsrlocContact struct {
+	Address string `json:"Address"`
+	// Expires is the seconds left, or permanent, expired or deleted
+	Expires      json.RawMessage `json:"Expires"`
+	Q            float64         `json:"Q"`
+	CallID       string          `json:"Call-ID"`
+	CSeq         int64           `json:"CSeq"`
+	UserAgent    string          `json:"User-Agent"`
+	Received     string          `json:"Received"`
+	Path         string          `json:"Path"`
+	State        string          `json:"State"`
+	Flags        int64           `json:"Flags"`
+	CFlags       int64           `json:"CFlags"`
+	Socket       string          `json:"Socket"`
+	Methods      int64           `json:"Methods"`
+	Ruid         string          `json:"Ruid"`
+	Instance     string          `json:"Instance"`
+	RegID        int64           `json:"Reg-Id"`
+	LastModified int64           `json:"Last-Modified"`
+}
+
+type UsrlocRecord struct {
+	AoR      string          `json:"AoR"`
+	HashID   int64           `json:"HashID"`
+	Contacts []UsrlocContact `json:"Contacts"`
+}
+
+// UsrlocLookup returns the contacts registered for aor in usrloc table
+func (a *API) UsrlocLookup(ctx context.Context, table, aor string) (UsrlocRecord, error) {
+	a.logger.Debug("ul lookup", zap.String("table", table), zap.String("aor", aor))
+	// each contact is wrapped, "Contacts":[{"Contact":{...}},...]
+	type contact struct {
+		Contact UsrlocContact `json:"Contact"`
+	}
+	type result struct {
+		AoR      string    `json:"AoR"`
+		HashID   int64     `json:"HashID"`
+		Contacts []contact `json:"Contacts"`
+	}
+	z := result{}
+	if err := a.call(ctx, "ul.lookup", []interface{}{table, aor}, &z); err != nil {
+		var e *RPCError
+		if errors.As(err, &e) && e.Code == http.StatusNotFound {
+			return UsrlocRecord{}, fmt.Errorf("%w [%s]", ErrAORNotFound, aor)
+		}
+		return UsrlocRecord{}, err
+	}
+	x := UsrlocRecord{AoR: z.AoR, HashID: z.HashID, Contacts: make([]UsrlocContact, 0, len(z.Contacts))}
+	for _, v := range z.Contacts {
+		c := v.Contact
+		for _, f := range []*string{&c.Received, &c.Path, &c.Socket, &c.Instance, &c.UserAgent} {
+			if *f == usrlocNotSet {
+				*f = ""
+			}
+		}
+		x.Contacts = append(x.Contacts, c)
+	}
+	return x, nil
+}
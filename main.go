@@ -1,6 +1,16 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/audit"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/auth"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/certreload"
 	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
 	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
 	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/log"
@@ -9,18 +19,100 @@ import (
 )
 
 func main() {
-	c := config.LoadConfig()
+	os.Exit(run())
+}
+
+// run starts the server and blocks until a shutdown signal or a server
+// failure. It returns the exit code so deferred cleanup, like flushing the
+// audit log, runs before the process exits.
+func run() int {
+	c, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
 
 	logger := log.New(c.Log.Level)
 	logger.Debug("debug enabled")
 
-	j, err := jsonrpcc.New(c.Kamailio.JSONRPC.Server.URL, logger)
+	cl := c.Kamailio.JSONRPC.Client
+	j, err := jsonrpcc.New(c.Kamailio.JSONRPC.Server.URL, jsonrpcc.Options{
+		Timeout:               cl.Timeout,
+		DisableKeepAlives:     !cl.KeepAlive,
+		MaxIdleConns:          cl.MaxIdleConns,
+		MaxIdleConnsPerHost:   cl.MaxIdleConns,
+		IdleConnTimeout:       cl.IdleConnTimeout,
+		DialTimeout:           cl.DialTimeout,
+		KeepAlive:             cl.TCPKeepAlive,
+		TLSHandshakeTimeout:   cl.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cl.ResponseHeaderTimeout,
+	}, logger)
+	if err != nil {
+		logger.Error("could not setup jsonrpcc", zap.Error(err))
+		return 1
+	}
+
+	a, err := auth.New(c.Auth, logger)
+	if err != nil {
+		logger.Error("could not setup auth", zap.Error(err))
+		return 1
+	}
+
+	l, err := audit.New(c.Audit, logger)
 	if err != nil {
-		logger.Fatal("could not setup jsonrpcc", zap.Error(err))
+		logger.Error("could not setup audit log", zap.Error(err))
+		return 1
+	}
+	defer l.Close()
+
+	var tlsConfig *tls.Config
+	cr, err := certreload.New(c.TLS, logger)
+	if err != nil {
+		logger.Error("could not setup tls", zap.Error(err))
+		return 1
+	}
+	if cr != nil {
+		defer cr.Close()
+		tlsConfig = cr.TLSConfig()
 	}
 
-	err = serverhttp.ListenAndServe(c.HTTPListenAddr, j, logger)
+	s := serverhttp.New(c.HTTPListenAddr, serverhttp.Options{
+		ReadTimeout:   c.HTTP.ReadTimeout,
+		WriteTimeout:  c.HTTP.WriteTimeout,
+		IdleTimeout:   c.HTTP.IdleTimeout,
+		MaxBodyBytes:  c.HTTP.MaxBodyBytes,
+		TLSConfig:     tlsConfig,
+		ReadyTimeout:  c.HTTP.ReadyTimeout,
+		ReadyCacheTTL: c.HTTP.ReadyCacheTTL,
+		IPBanTable:    c.Kamailio.HTable.IPBan,
+		UsrlocTable:   c.Kamailio.Usrloc.Table,
+	}, j, a, l, logger)
+	err = s.Start()
 	if err != nil {
-		logger.Fatal("could not setup http server", zap.Error(err))
+		logger.Error("could not setup http server", zap.Error(err))
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	failed := false
+	select {
+	case <-ctx.Done():
+		logger.Info("received shutdown signal")
+	case err := <-s.Err():
+		logger.Error("http server stopped", zap.Error(err))
+		failed = true
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), c.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		logger.Error("graceful shutdown incomplete", zap.Error(err))
+	}
+	logger.Info("shutdown complete")
+	if failed {
+		return 1
 	}
+	return 0
 }
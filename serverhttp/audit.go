@@ -0,0 +1,98 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/audit"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/auth"
+)
+
+// auditMaxLimit caps the records returned by one audit query
+const auditMaxLimit = 1000
+
+// audit records a mutating operation that started at start and finished with err
+func (h httpHandler) audit(r *http.Request, operation string, params map[string]string, start time.Time, err error) {
+	id, _ := auth.FromContext(r.Context())
+	ip, _, splitErr := net.SplitHostPort(r.RemoteAddr)
+	if splitErr != nil {
+		ip = r.RemoteAddr
+	}
+	h.auditLog.Write(auditRecord(id, ip, operation, params, start, err))
+}
+
+// auditSystem records an operation the service started on its own, such as a scheduled revert
+func (h httpHandler) auditSystem(operation string, params map[string]string, start time.Time, err error) {
+	h.auditLog.Write(auditRecord(auth.Identity{Name: "system", Method: "none"}, "", operation, params, start, err))
+}
+
+func auditRecord(id auth.Identity, ip string, operation string, params map[string]string, start time.Time, err error) audit.Record {
+	x := audit.Record{
+		Time:       start.UTC(),
+		Identity:   id.Name,
+		AuthMethod: id.Method,
+		SourceIP:   ip,
+		Operation:  operation,
+		Params:     params,
+		Result:     "ok",
+		LatencyMS:  float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		x.Result = "error"
+		x.Error = err.Error()
+	}
+	return x
+}
+
+func (h httpHandler) auditList(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	if !h.auditLog.Enabled() {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("audit log disabled")
+		return
+	}
+	f := audit.Filter{
+		Identity:  r.FormValue("identity"),
+		Operation: r.FormValue("operation"),
+		Limit:     100,
+	}
+	if v := r.FormValue("since"); v != "" {
+		f.Since, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("since must be RFC3339")
+			return
+		}
+	}
+	if v := r.FormValue("until"); v != "" {
+		f.Until, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("until must be RFC3339")
+			return
+		}
+	}
+	if v := r.FormValue("limit"); v != "" {
+		f.Limit, err = strconv.Atoi(v)
+		if err != nil || f.Limit < 1 || f.Limit > auditMaxLimit {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("limit must be between 1 and " + strconv.Itoa(auditMaxLimit))
+			return
+		}
+	}
+	x, err := h.auditLog.Query(f)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
@@ -0,0 +1,155 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"go.uber.org/zap"
+	"goji.io/pat"
+)
+
+type banRequest struct {
+	Reason string `json:"reason"`
+	// TTL is a duration such as 30m or 24h, empty leaves expiry to the
+	// htable autoexpire
+	TTL string `json:"ttl"`
+}
+
+func (b banRequest) ttl() (time.Duration, error) {
+	if b.TTL == "" {
+		return 0, nil
+	}
+	x, err := time.ParseDuration(b.TTL)
+	if err != nil {
+		return 0, err
+	}
+	// htable expiry has second granularity
+	if x < 0 || (x > 0 && x < time.Second) {
+		return 0, fmt.Errorf("ttl [%s] is below 1s", b.TTL)
+	}
+	return x, nil
+}
+
+func (h httpHandler) bansList(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.ListIPBans(r.Context(), h.ipBanTable)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) bansAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	type request struct {
+		IP string `json:"ip"`
+		banRequest
+	}
+	z := request{}
+	err := json.NewDecoder(r.Body).Decode(&z)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if net.ParseIP(z.IP) == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing or invalid ip")
+		return
+	}
+	ttl, err := z.ttl()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("ttl must be a duration of at least 1s such as 30m or 24h")
+		return
+	}
+	start := time.Now()
+	err = h.jsonrpcAPI.BanIP(ctx, h.ipBanTable, z.IP, z.Reason, ttl)
+	h.audit(r, "bans.add", map[string]string{"table": h.ipBanTable, "ip": z.IP, "reason": z.Reason, "ttl": z.TTL}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) bansImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	type request struct {
+		CIDRs []string `json:"cidrs"`
+		banRequest
+	}
+	z := request{}
+	err := json.NewDecoder(r.Body).Decode(&z)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	ttl, err := z.ttl()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("ttl must be a duration of at least 1s such as 30m or 24h")
+		return
+	}
+	ips, err := jsonrpcc.ExpandCIDRs(z.CIDRs)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	type response struct {
+		Banned int      `json:"banned"`
+		Failed []string `json:"failed"`
+	}
+	x := response{Failed: []string{}}
+	cidrs, _ := json.Marshal(z.CIDRs)
+	params := map[string]string{"table": h.ipBanTable, "cidrs": string(cidrs), "reason": z.Reason, "ttl": z.TTL}
+	start := time.Now()
+	errs, err := h.jsonrpcAPI.BanIPs(ctx, h.ipBanTable, ips, z.Reason, ttl)
+	if err != nil {
+		h.audit(r, "bans.import", params, start, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	for i, ip := range ips {
+		if errs[i] != nil {
+			h.logger.Error("could not ban ip", zap.Error(errs[i]), zap.String("table", h.ipBanTable), zap.String("ip", ip))
+			x.Failed = append(x.Failed, ip)
+			continue
+		}
+		x.Banned++
+	}
+	var auditErr error
+	if len(x.Failed) > 0 {
+		auditErr = fmt.Errorf("%d of %d addresses failed", len(x.Failed), len(ips))
+	}
+	h.audit(r, "bans.import", params, start, auditErr)
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) bansDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ip := pat.Param(r, "ip")
+	if net.ParseIP(ip) == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing or invalid ip")
+		return
+	}
+	start := time.Now()
+	err := h.jsonrpcAPI.UnbanIP(ctx, h.ipBanTable, ip)
+	h.audit(r, "bans.delete", map[string]string{"table": h.ipBanTable, "ip": ip}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
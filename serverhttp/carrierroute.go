@@ -0,0 +1,67 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"goji.io/pat"
+)
+
+func (h httpHandler) carrierRouteDump(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CarrierRouteDump(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) carrierRouteReload(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.CarrierRouteReload(r.Context())
+	h.audit(r, "cr.reload_routes", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) carrierRouteHost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	action := pat.Param(r, "action")
+	if action != "activate" && action != "deactivate" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("action must be activate or deactivate")
+		return
+	}
+	z := jsonrpcc.CarrierRouteHost{}
+	err := json.NewDecoder(r.Body).Decode(&z)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if z.Carrier == "" || z.Domain == "" || z.Host == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing carrier, domain or host")
+		return
+	}
+	start := time.Now()
+	if action == "activate" {
+		err = h.jsonrpcAPI.CarrierRouteActivateHost(ctx, z)
+	} else {
+		err = h.jsonrpcAPI.CarrierRouteDeactivateHost(ctx, z)
+	}
+	h.audit(r, "cr."+action+"_host", map[string]string{"carrier": z.Carrier, "domain": z.Domain, "prefix": z.Prefix, "host": z.Host}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,182 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"goji.io/pat"
+)
+
+// cfgValue converts a value decoded with UseNumber into the int or string
+// the cfg framework expects
+func cfgValue(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case json.Number:
+		i, err := strconv.Atoi(x.String())
+		if err != nil {
+			return nil, fmt.Errorf("value [%s] is not an integer", x)
+		}
+		return i, nil
+	case string:
+		return x, nil
+	}
+	return nil, errors.New("value must be an integer or a string")
+}
+
+func (h httpHandler) cfgList(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CfgList(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) cfgDiff(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CfgDiff(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) cfgGet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	group := pat.Param(r, "group")
+	name := pat.Param(r, "var")
+	x, err := h.jsonrpcAPI.CfgGet(ctx, group, name)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) cfgPut(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	group := pat.Param(r, "group")
+	name := pat.Param(r, "var")
+	type request struct {
+		Value interface{} `json:"value"`
+	}
+	z := request{}
+	d := json.NewDecoder(r.Body)
+	d.UseNumber()
+	if err := d.Decode(&z); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	value, err := cfgValue(z.Value)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	delayed := r.URL.Query().Get("delayed") == "true"
+	operation := "cfg.set"
+	start := time.Now()
+	switch v := value.(type) {
+	case int:
+		if delayed {
+			operation = "cfg.set_delayed_int"
+			err = h.jsonrpcAPI.CfgSetDelayedInt(ctx, group, name, v)
+		} else {
+			operation = "cfg.seti"
+			err = h.jsonrpcAPI.CfgSeti(ctx, group, name, v)
+		}
+	case string:
+		if delayed {
+			operation = "cfg.set_delayed_string"
+			err = h.jsonrpcAPI.CfgSetDelayedString(ctx, group, name, v)
+		} else {
+			operation = "cfg.sets"
+			err = h.jsonrpcAPI.CfgSets(ctx, group, name, v)
+		}
+	}
+	h.audit(r, operation, map[string]string{"group": group, "var": name, "value": fmt.Sprint(value)}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) cfgApply(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	type request struct {
+		Changes []jsonrpcc.CfgChange `json:"changes"`
+	}
+	z := request{}
+	d := json.NewDecoder(r.Body)
+	d.UseNumber()
+	if err := d.Decode(&z); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if len(z.Changes) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing changes")
+		return
+	}
+	params := map[string]string{}
+	for i, c := range z.Changes {
+		if c.Group == "" || c.Var == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("every change needs group and var")
+			return
+		}
+		v, err := cfgValue(c.Value)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(fmt.Sprintf("%s.%s: %s", c.Group, c.Var, err))
+			return
+		}
+		z.Changes[i].Value = v
+		params[c.Group+"."+c.Var] = fmt.Sprint(v)
+	}
+	start := time.Now()
+	err := h.jsonrpcAPI.CfgApply(ctx, z.Changes)
+	h.audit(r, "cfg.commit", params, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) cfgCommit(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.CfgCommit(r.Context())
+	h.audit(r, "cfg.commit", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) cfgRollback(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.CfgRollback(r.Context())
+	h.audit(r, "cfg.rollback", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,132 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+)
+
+func (h httpHandler) coreSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	type response struct {
+		Info    jsonrpcc.CoreInfoResult   `json:"info"`
+		Uptime  jsonrpcc.CoreUptimeResult `json:"uptime"`
+		Modules []string                  `json:"modules"`
+	}
+	var err error
+	x := response{}
+	x.Info, err = h.jsonrpcAPI.CoreInfo(ctx)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	x.Uptime, err = h.jsonrpcAPI.CoreUptime(ctx)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	x.Modules, err = h.jsonrpcAPI.CoreModules(ctx)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) coreVersion(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CoreVersion(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) coreUptime(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CoreUptime(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) coreInfo(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CoreInfo(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) coreProcesses(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CoreProcesses(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) coreShmMem(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	unit := r.FormValue("unit")
+	switch unit {
+	case "", "b", "k", "m", "g":
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("unit must be one of b, k, m or g")
+		return
+	}
+	x, err := h.jsonrpcAPI.CoreShmMem(r.Context(), unit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) coreTCPInfo(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CoreTCPInfo(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) coreSockets(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CoreSockets(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) coreModules(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CoreModules(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
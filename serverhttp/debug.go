@@ -0,0 +1,223 @@
+package serverhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/auth"
+	"go.uber.org/zap"
+)
+
+const (
+	debugGroup           = "core"
+	debugVar             = "debug"
+	debugDefaultDuration = 10 * time.Minute
+	debugMaxDuration     = 24 * time.Hour
+	debugRevertTimeout   = 10 * time.Second
+	debugRetryMin        = time.Second
+	debugRetryMax        = time.Minute
+)
+
+// debugRevert is a temporary core.debug change waiting to be reverted
+type debugRevert struct {
+	OriginalLevel int       `json:"original_level"`
+	Level         int       `json:"level"`
+	SetBy         string    `json:"set_by"`
+	StartedAt     time.Time `json:"started_at"`
+	RevertAt      time.Time `json:"revert_at"`
+	stop          chan struct{}
+}
+
+// debugEscalation tracks the pending revert. State only lives in process, a
+// pending revert is applied on graceful shutdown.
+type debugEscalation struct {
+	// change orders the kamailio calls that change core.debug so a second
+	// escalation reads the level before the first, mu only guards pending
+	// and is never held across a kamailio call
+	change  sync.Mutex
+	mu      sync.Mutex
+	pending *debugRevert
+}
+
+func (h httpHandler) debugGet(w http.ResponseWriter, r *http.Request) {
+	h.debug.mu.Lock()
+	x := h.debug.pending
+	h.debug.mu.Unlock()
+	if x == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("no pending debug revert")
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) debugSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	level, err := strconv.Atoi(r.FormValue("level"))
+	if err != nil || level < -5 || level > 3 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("level must be an integer between -5 and 3")
+		return
+	}
+	duration := debugDefaultDuration
+	if v := r.FormValue("duration"); v != "" {
+		duration, err = time.ParseDuration(v)
+		if err != nil || duration <= 0 || duration > debugMaxDuration {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("duration must be a positive duration up to 24h")
+			return
+		}
+	}
+
+	h.debug.change.Lock()
+	defer h.debug.change.Unlock()
+	h.debug.mu.Lock()
+	prev := h.debug.pending
+	h.debug.mu.Unlock()
+	// a new request while one is pending keeps the level from before the
+	// first escalation so the final revert restores the real setting
+	original := 0
+	if prev != nil {
+		original = prev.OriginalLevel
+	} else {
+		x, err := h.jsonrpcAPI.CfgGet(ctx, debugGroup, debugVar)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+		original, err = strconv.Atoi(x.Value)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("unexpected core.debug value " + x.Value)
+			return
+		}
+	}
+	start := time.Now()
+	err = h.jsonrpcAPI.CfgSeti(ctx, debugGroup, debugVar, level)
+	h.audit(r, "debug.set", map[string]string{"level": strconv.Itoa(level), "original_level": strconv.Itoa(original), "duration": duration.String()}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	id, _ := auth.FromContext(ctx)
+	x := &debugRevert{
+		OriginalLevel: original,
+		Level:         level,
+		SetBy:         id.Name,
+		StartedAt:     start.UTC(),
+		RevertAt:      start.Add(duration).UTC(),
+		stop:          make(chan struct{}),
+	}
+	h.debug.mu.Lock()
+	// pending is prev, or nil when a cancel without revert dropped it
+	if h.debug.pending != nil {
+		close(h.debug.pending.stop)
+	}
+	h.debug.pending = x
+	h.debug.mu.Unlock()
+	h.server.Go(func(ctx context.Context) {
+		t := time.NewTimer(duration)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			h.debugRevert(x, "shutdown")
+			return
+		case <-x.stop:
+			return
+		}
+		// keep retrying while kamailio is unreachable so the raised level
+		// never outlives the worker
+		backoff := debugRetryMin
+		for h.debugRevert(x, "expired") != nil {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				h.debugRevert(x, "shutdown")
+				return
+			case <-x.stop:
+				return
+			}
+			backoff = min(backoff*2, debugRetryMax)
+		}
+	})
+	h.logger.Info("kamailio debug level raised", zap.Int("level", level), zap.Int("original_level", original), zap.Time("revert_at", x.RevertAt))
+	json.NewEncoder(w).Encode(x)
+}
+
+// debugCancel reverts the pending change now, or with revert=false drops the
+// scheduled revert and leaves the current level in place
+func (h httpHandler) debugCancel(w http.ResponseWriter, r *http.Request) {
+	h.debug.mu.Lock()
+	x := h.debug.pending
+	h.debug.mu.Unlock()
+	if x == nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("no pending debug revert")
+		return
+	}
+	if r.URL.Query().Get("revert") == "false" {
+		h.debug.mu.Lock()
+		if h.debug.pending == x {
+			close(x.stop)
+			h.debug.pending = nil
+		}
+		h.debug.mu.Unlock()
+		h.audit(r, "debug.cancel", map[string]string{"level": strconv.Itoa(x.Level)}, time.Now(), nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	start := time.Now()
+	err := h.debugRevert(x, "cancelled")
+	h.audit(r, "debug.revert", map[string]string{"level": strconv.Itoa(x.OriginalLevel)}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// debugRevert restores the original level if x is still the pending change
+func (h httpHandler) debugRevert(x *debugRevert, reason string) error {
+	h.debug.change.Lock()
+	defer h.debug.change.Unlock()
+	h.debug.mu.Lock()
+	pending := h.debug.pending
+	h.debug.mu.Unlock()
+	if pending != x {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), debugRevertTimeout)
+	defer cancel()
+	start := time.Now()
+	err := h.jsonrpcAPI.CfgSeti(ctx, debugGroup, debugVar, x.OriginalLevel)
+	if reason != "cancelled" {
+		h.auditSystem("debug.revert", map[string]string{"level": strconv.Itoa(x.OriginalLevel), "reason": reason}, start, err)
+	}
+	if err != nil {
+		h.logger.Error("could not revert kamailio debug level", zap.Error(err), zap.Int("level", x.OriginalLevel), zap.String("reason", reason))
+		return err
+	}
+	h.debug.mu.Lock()
+	// a cancel without revert may have dropped x during the call
+	if h.debug.pending == x {
+		close(x.stop)
+		h.debug.pending = nil
+	}
+	h.debug.mu.Unlock()
+	h.logger.Info("kamailio debug level reverted", zap.Int("level", x.OriginalLevel), zap.String("reason", reason))
+	return nil
+}
@@ -0,0 +1,177 @@
+package serverhttp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+)
+
+// fakeDebugLevel is core.debug in a fake kamailio. cfg.seti blocks while
+// gate is set.
+type fakeDebugLevel struct {
+	mu    sync.Mutex
+	level int
+	gate  chan struct{}
+}
+
+func (d *fakeDebugLevel) get() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.level
+}
+
+func (d *fakeDebugLevel) results() map[string]interface{} {
+	return map[string]interface{}{
+		"system.listMethods": []string{"cfg.get", "cfg.seti", "system.listMethods"},
+		"cfg.get": func([]json.RawMessage) interface{} {
+			return d.get()
+		},
+		"cfg.seti": func(params []json.RawMessage) interface{} {
+			d.mu.Lock()
+			gate := d.gate
+			d.mu.Unlock()
+			if gate != nil {
+				<-gate
+			}
+			var level int
+			json.Unmarshal(params[2], &level)
+			d.mu.Lock()
+			d.level = level
+			d.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+func debugRequest(t *testing.T, method, url string) (int, debugRevert) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	x := debugRevert{}
+	b, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(b, &x)
+	return resp.StatusCode, x
+}
+
+func TestDebugEscalation(t *testing.T) {
+	d := &fakeDebugLevel{}
+	s := newTestServer(t, fakeKamailio(t, d.results()).URL, config.Auth{})
+	defer s.Shutdown(context.Background())
+	url := "http://" + s.Addr().String() + "/v1/debug"
+
+	if code, _ := debugRequest(t, http.MethodGet, url); code != http.StatusNotFound {
+		t.Fatalf("GET with nothing pending = %d, want 404", code)
+	}
+	code, x := debugRequest(t, http.MethodPost, url+"?level=3&duration=1h")
+	if code != http.StatusOK || x.Level != 3 || x.OriginalLevel != 0 || d.get() != 3 {
+		t.Fatalf("POST = %d %+v, kamailio level %d", code, x, d.get())
+	}
+	// a second escalation keeps the level from before the first
+	code, x = debugRequest(t, http.MethodPost, url+"?level=2&duration=1h")
+	if code != http.StatusOK || x.Level != 2 || x.OriginalLevel != 0 || d.get() != 2 {
+		t.Fatalf("second POST = %d %+v, kamailio level %d", code, x, d.get())
+	}
+	if code, x := debugRequest(t, http.MethodGet, url); code != http.StatusOK || x.Level != 2 {
+		t.Fatalf("GET = %d %+v", code, x)
+	}
+	if code, _ := debugRequest(t, http.MethodDelete, url); code != http.StatusNoContent || d.get() != 0 {
+		t.Fatalf("DELETE = %d, kamailio level %d", code, d.get())
+	}
+	if code, _ := debugRequest(t, http.MethodGet, url); code != http.StatusNotFound {
+		t.Fatalf("GET after revert = %d, want 404", code)
+	}
+
+	// the worker reverts once the duration expires
+	if code, _ := debugRequest(t, http.MethodPost, url+"?level=3&duration=50ms"); code != http.StatusOK {
+		t.Fatalf("POST = %d", code)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		code, _ := debugRequest(t, http.MethodGet, url)
+		if code == http.StatusNotFound {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GET after expiry = %d, want 404", code)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if d.get() != 0 {
+		t.Fatalf("kamailio level %d after expiry, want 0", d.get())
+	}
+
+	// revert=false keeps the level and drops the revert
+	debugRequest(t, http.MethodPost, url+"?level=3&duration=50ms")
+	if code, _ := debugRequest(t, http.MethodDelete, url+"?revert=false"); code != http.StatusNoContent {
+		t.Fatalf("DELETE revert=false = %d", code)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if d.get() != 3 {
+		t.Fatalf("kamailio level %d after cancel without revert, want 3", d.get())
+	}
+}
+
+func TestDebugGetDoesNotWaitForKamailio(t *testing.T) {
+	d := &fakeDebugLevel{gate: make(chan struct{})}
+	s := newTestServer(t, fakeKamailio(t, d.results()).URL, config.Auth{})
+	defer s.Shutdown(context.Background())
+	url := "http://" + s.Addr().String() + "/v1/debug"
+
+	done := make(chan int)
+	go func() {
+		code, _ := debugRequest(t, http.MethodPost, url+"?level=3&duration=1h")
+		done <- code
+	}()
+	// give the POST time to reach the blocked cfg.seti
+	time.Sleep(100 * time.Millisecond)
+	got := make(chan int)
+	go func() {
+		code, _ := debugRequest(t, http.MethodGet, url)
+		got <- code
+	}()
+	select {
+	case code := <-got:
+		if code != http.StatusNotFound {
+			t.Fatalf("GET during escalation = %d, want 404", code)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("GET blocked behind a pending cfg.seti")
+	}
+	d.mu.Lock()
+	close(d.gate)
+	d.gate = nil
+	d.mu.Unlock()
+	if code := <-done; code != http.StatusOK {
+		t.Fatalf("POST = %d", code)
+	}
+}
+
+func TestDebugRevertOnShutdown(t *testing.T) {
+	d := &fakeDebugLevel{}
+	s := newTestServer(t, fakeKamailio(t, d.results()).URL, config.Auth{})
+	url := "http://" + s.Addr().String() + "/v1/debug"
+	if code, _ := debugRequest(t, http.MethodPost, url+"?level=3&duration=1h"); code != http.StatusOK {
+		t.Fatalf("POST = %d", code)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d.get() != 0 {
+		t.Fatalf("kamailio level %d after shutdown, want 0", d.get())
+	}
+}
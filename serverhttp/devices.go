@@ -0,0 +1,120 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"goji.io/pat"
+)
+
+func (h httpHandler) deviceContacts(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.UsrlocLookup(r.Context(), h.usrlocTable, pat.Param(r, "aor"))
+	if errors.Is(err, jsonrpcc.ErrAORNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+// deviceCheckSync sends NOTIFY check-sync to every contact registered for the
+// aor and returns the final reply of each
+func (h httpHandler) deviceCheckSync(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	aor := pat.Param(r, "aor")
+	event := "check-sync"
+	switch v := r.FormValue("reboot"); v {
+	case "":
+	case "true", "false":
+		event += ";reboot=" + v
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("reboot must be true or false")
+		return
+	}
+	start := time.Now()
+	x, err := h.jsonrpcAPI.UsrlocLookup(ctx, h.usrlocTable, aor)
+	if errors.Is(err, jsonrpcc.ErrAORNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if err != nil {
+		h.audit(r, "devices.check_sync", map[string]string{"aor": aor}, start, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if len(x.Contacts) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("no registered contacts")
+		return
+	}
+	type result struct {
+		Contact string `json:"contact"`
+		Code    int    `json:"code,omitempty"`
+		Reason  string `json:"reason,omitempty"`
+		Error   string `json:"error,omitempty"`
+	}
+	uri := aor
+	if !strings.HasPrefix(uri, "sip:") && !strings.HasPrefix(uri, "sips:") {
+		uri = "sip:" + uri
+	}
+	z := make([]result, len(x.Contacts))
+	wg := sync.WaitGroup{}
+	for i, c := range x.Contacts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := jsonrpcc.SIPRequest{
+				Method: "NOTIFY",
+				RURI:   c.Address,
+				Headers: map[string]string{
+					"To":                 "<" + uri + ">",
+					"From":               "<" + uri + ">",
+					"Event":              event,
+					"Subscription-State": "terminated",
+				},
+				// reach contacts behind nat on their received address and
+				// socket as registered
+				OutboundProxy: c.Received,
+				Socket:        c.Socket,
+			}
+			if c.Path != "" {
+				req.Headers["Route"] = c.Path
+			}
+			z[i].Contact = c.Address
+			reply, err := h.jsonrpcAPI.SIPSendWait(ctx, req)
+			if err != nil {
+				z[i].Error = err.Error()
+				return
+			}
+			z[i].Code = reply.Code
+			z[i].Reason = reply.Reason
+		}()
+	}
+	wg.Wait()
+	failed := 0
+	for _, v := range z {
+		if v.Error != "" {
+			failed++
+		}
+	}
+	err = nil
+	if failed > 0 {
+		err = fmt.Errorf("%d of %d contacts failed", failed, len(z))
+	}
+	h.audit(r, "devices.check_sync", map[string]string{"aor": aor, "event": event}, start, err)
+	json.NewEncoder(w).Encode(z)
+}
@@ -0,0 +1,71 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"goji.io/pat"
+)
+
+func (h httpHandler) dialplanDump(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	dpid, err := strconv.Atoi(pat.Param(r, "dpid"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("dpid must be an integer")
+		return
+	}
+	x, err := h.jsonrpcAPI.DialplanDump(ctx, dpid)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) dialplanTranslate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	dpid, err := strconv.Atoi(pat.Param(r, "dpid"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("dpid must be an integer")
+		return
+	}
+	type request struct {
+		Input string `json:"input"`
+	}
+	z := request{}
+	err = json.NewDecoder(r.Body).Decode(&z)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if z.Input == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing input")
+		return
+	}
+	x, err := h.jsonrpcAPI.DialplanTranslate(ctx, dpid, z.Input)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) dialplanReload(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.DialplanReload(r.Context())
+	h.audit(r, "dialplan.reload", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
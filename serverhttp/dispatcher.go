@@ -3,6 +3,7 @@ package serverhttp
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"goji.io/pat"
 )
@@ -45,7 +46,9 @@ func (h httpHandler) dispatcherAdd(w http.ResponseWriter, r *http.Request) {
 	flags := ""
 	priority := ""
 	attrs := ""
+	start := time.Now()
 	err := h.jsonrpcAPI.DispatcherAdd(ctx, group, addr, flags, priority, attrs)
+	h.audit(r, "dispatcher.add", map[string]string{"group": group, "addr": addr}, start, err)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(err.Error())
@@ -68,7 +71,9 @@ func (h httpHandler) dispatcherRemove(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode("must provide action")
 		return
 	}
+	start := time.Now()
 	err := h.jsonrpcAPI.DispatcherRemove(ctx, group, addr)
+	h.audit(r, "dispatcher.remove", map[string]string{"group": group, "addr": addr}, start, err)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(err.Error())
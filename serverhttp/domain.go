@@ -0,0 +1,60 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"goji.io/pat"
+)
+
+func (h httpHandler) domainList(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.DomainDump(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) domainGet(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.DomainDump(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	domain := pat.Param(r, "domain")
+	for _, d := range x {
+		if strings.EqualFold(d.Domain, domain) {
+			json.NewEncoder(w).Encode(d)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+	json.NewEncoder(w).Encode("domain not found")
+}
+
+func (h httpHandler) domainReload(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.DomainReload(r.Context())
+	h.audit(r, "domain.reload", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) domainAliases(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.CorexListAliases(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
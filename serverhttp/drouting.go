@@ -0,0 +1,106 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"goji.io/pat"
+)
+
+func (h httpHandler) droutingDump(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.DroutingDump(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) droutingReload(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.DroutingReload(r.Context())
+	h.audit(r, "drouting.reload", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) droutingGateways(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.DroutingGatewayStatus(r.Context(), "")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) droutingGateway(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.DroutingGatewayStatus(r.Context(), pat.Param(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if len(x) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("gateway not found")
+		return
+	}
+	json.NewEncoder(w).Encode(x[0])
+}
+
+func (h httpHandler) droutingGatewayPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := pat.Param(r, "id")
+	action := r.FormValue("action")
+	if action != "enable" && action != "disable" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("action must be enable or disable")
+		return
+	}
+	start := time.Now()
+	err := h.jsonrpcAPI.DroutingSetGatewayStatus(ctx, id, action == "enable")
+	h.audit(r, "drouting.gw_status", map[string]string{"id": id, "action": action}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) droutingRules(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.DroutingRuleStatus(r.Context(), "")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) droutingRulePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := pat.Param(r, "id")
+	action := r.FormValue("action")
+	if action != "enable" && action != "disable" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("action must be enable or disable")
+		return
+	}
+	start := time.Now()
+	err := h.jsonrpcAPI.DroutingSetRuleStatus(ctx, id, action == "enable")
+	h.audit(r, "drouting.rule_status", map[string]string{"id": id, "action": action}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package serverhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"go.uber.org/zap"
+)
+
+type targetStatus struct {
+	Target    string  `json:"target"`
+	Status    string  `json:"status"`
+	Version   string  `json:"version,omitempty"`
+	Uptime    int64   `json:"uptime,omitempty"`
+	UpSince   string  `json:"up_since,omitempty"`
+	Error     string  `json:"error,omitempty"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+type readyStatus struct {
+	Status    string         `json:"status"`
+	CheckedAt time.Time      `json:"checked_at"`
+	Targets   []targetStatus `json:"targets"`
+}
+
+// readiness caches the last kamailio probe so frequent kubelet probes do not
+// turn into a jsonrpc call each
+type readiness struct {
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu   sync.Mutex
+	last readyStatus
+}
+
+func (h httpHandler) healthz(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+func (h httpHandler) readyz(w http.ResponseWriter, r *http.Request) {
+	x := h.ready.check(r.Context(), h)
+	if x.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (rd *readiness) check(ctx context.Context, h httpHandler) readyStatus {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+	if !rd.last.CheckedAt.IsZero() && time.Since(rd.last.CheckedAt) < rd.ttl {
+		return rd.last
+	}
+	ctx, cancel := context.WithTimeout(ctx, rd.timeout)
+	defer cancel()
+	x := readyStatus{
+		Status:    "ok",
+		CheckedAt: time.Now().UTC(),
+		Targets:   []targetStatus{h.probe(ctx)},
+	}
+	for _, t := range x.Targets {
+		if t.Status != "ok" {
+			x.Status = "unavailable"
+		}
+	}
+	rd.last = x
+	return x
+}
+
+func (h httpHandler) probe(ctx context.Context) targetStatus {
+	start := time.Now()
+	t := targetStatus{Target: h.jsonrpcAPI.URL(), Status: "ok"}
+	v, err := h.jsonrpcAPI.CoreVersion(ctx)
+	if err == nil {
+		t.Version = v
+		var u jsonrpcc.CoreUptimeResult
+		u, err = h.jsonrpcAPI.CoreUptime(ctx)
+		t.Uptime = u.Uptime
+		t.UpSince = u.UpSince
+	}
+	t.LatencyMS = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		h.logger.Warn("kamailio readiness probe failed", zap.Error(err), zap.String("target", t.Target))
+		t.Status = "unavailable"
+		t.Error = err.Error()
+	}
+	return t
+}
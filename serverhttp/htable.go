@@ -3,7 +3,9 @@ package serverhttp
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
 	"go.uber.org/zap"
 	"goji.io/pat"
 )
@@ -76,7 +78,9 @@ func (h httpHandler) htablePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if action == "flush" {
+		start := time.Now()
 		err := h.jsonrpcAPI.HTableFlush(ctx, table)
+		h.audit(r, "htable.flush", map[string]string{"table": table}, start, err)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(err.Error())
@@ -86,7 +90,9 @@ func (h httpHandler) htablePost(w http.ResponseWriter, r *http.Request) {
 	if action == "set" {
 		key := r.FormValue("key")
 		value := r.FormValue("value")
+		start := time.Now()
 		err := h.jsonrpcAPI.HTableSets(ctx, table, key, value)
+		h.audit(r, "htable.sets", map[string]string{"table": table, "key": key, "value": value}, start, err)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(err.Error())
@@ -110,7 +116,9 @@ func (h httpHandler) htableDelete(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode("missing key")
 		return
 	}
+	start := time.Now()
 	err := h.jsonrpcAPI.HTableDelete(ctx, table, key)
+	h.audit(r, "htable.delete", map[string]string{"table": table, "key": key}, start, err)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(err.Error())
@@ -142,14 +150,11 @@ func (h httpHandler) htableDeleteQuery(w http.ResponseWriter, r *http.Request) {
 			json.NewEncoder(w).Encode(err.Error())
 			return
 		}
-		for _, r := range n {
-			for _, v := range r.Slot {
-				h.logger.Debug("deleting record containing name", zap.String("table", table), zap.String("name", v.Name), zap.String("value", keyContains))
-				err := h.jsonrpcAPI.HTableDelete(ctx, table, v.Name)
-				if err != nil {
-					h.logger.Error("could not delete record containing name", zap.Error(err), zap.String("table", table), zap.String("name", v.Name), zap.String("value", keyContains))
-				}
-			}
+		err = h.htableDeleteSlots(r, table, n, "key_contains", keyContains)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(err.Error())
+			return
 		}
 	}
 
@@ -160,16 +165,43 @@ func (h httpHandler) htableDeleteQuery(w http.ResponseWriter, r *http.Request) {
 			json.NewEncoder(w).Encode(err.Error())
 			return
 		}
-		for _, r := range v {
-			for _, v := range r.Slot {
-				h.logger.Debug("deleting record containing value", zap.String("table", table), zap.String("name", v.Name), zap.String("value", valueContains))
-				err := h.jsonrpcAPI.HTableDelete(ctx, table, v.Name)
-				if err != nil {
-					h.logger.Error("could not delete record containing value", zap.Error(err), zap.String("table", table), zap.String("name", v.Name), zap.String("value", valueContains))
-				}
-			}
+		err = h.htableDeleteSlots(r, table, v, "value_contains", valueContains)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(err.Error())
+			return
 		}
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// htableDeleteSlots deletes every record of the query result in batches,
+// auditing each key under the query param that matched it
+func (h httpHandler) htableDeleteSlots(r *http.Request, table string, n []jsonrpcc.HTableDumpResult, param, value string) error {
+	keys := []string{}
+	for _, x := range n {
+		for _, v := range x.Slot {
+			h.logger.Debug("deleting record", zap.String("table", table), zap.String("name", v.Name), zap.String(param, value))
+			keys = append(keys, v.Name)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	start := time.Now()
+	errs, err := h.jsonrpcAPI.HTableDeleteKeys(r.Context(), table, keys)
+	if err != nil {
+		for _, k := range keys {
+			h.audit(r, "htable.delete", map[string]string{"table": table, "key": k, param: value}, start, err)
+		}
+		return err
+	}
+	for i, k := range keys {
+		h.audit(r, "htable.delete", map[string]string{"table": table, "key": k, param: value}, start, errs[i])
+		if errs[i] != nil {
+			h.logger.Error("could not delete record", zap.Error(errs[i]), zap.String("table", table), zap.String("name", k), zap.String(param, value))
+		}
+	}
+	return nil
+}
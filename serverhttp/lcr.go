@@ -0,0 +1,73 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"goji.io/pat"
+)
+
+func (h httpHandler) lcrGateways(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.LCRDumpGateways(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) lcrRules(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.LCRDumpRules(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) lcrReload(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.LCRReload(r.Context())
+	h.audit(r, "lcr.reload", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) lcrDefunctGateway(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	lcrID, err := strconv.ParseInt(pat.Param(r, "lcr_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("lcr_id must be an integer")
+		return
+	}
+	gwID, err := strconv.ParseInt(pat.Param(r, "gw_id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("gw_id must be an integer")
+		return
+	}
+	period, err := strconv.ParseInt(r.FormValue("period"), 10, 64)
+	if err != nil || period < 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("period must be a positive number of seconds")
+		return
+	}
+	start := time.Now()
+	err = h.jsonrpcAPI.LCRDefunctGateway(ctx, lcrID, gwID, period)
+	h.audit(r, "lcr.defunct_gw", map[string]string{"lcr_id": strconv.FormatInt(lcrID, 10), "gw_id": strconv.FormatInt(gwID, 10), "period": strconv.FormatInt(period, 10)}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,48 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"goji.io/pat"
+)
+
+func (h httpHandler) mtreeList(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.MtreeList(r.Context(), r.FormValue("table"))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) mtreeMatch(w http.ResponseWriter, r *http.Request) {
+	prefix := r.FormValue("prefix")
+	if prefix == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing prefix param")
+		return
+	}
+	x, err := h.jsonrpcAPI.MtreeMatch(r.Context(), pat.Param(r, "table"), prefix)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) mtreeReload(w http.ResponseWriter, r *http.Request) {
+	table := r.FormValue("table")
+	start := time.Now()
+	err := h.jsonrpcAPI.MtreeReload(r.Context(), table)
+	h.audit(r, "mtree.reload", map[string]string{"table": table}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
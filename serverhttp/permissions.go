@@ -0,0 +1,124 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"goji.io/pat"
+)
+
+func (h httpHandler) permissionsDump(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var x interface{}
+	var err error
+	switch pat.Param(r, "table") {
+	case "address":
+		x, err = h.jsonrpcAPI.PermissionsAddressDump(ctx)
+	case "subnet":
+		x, err = h.jsonrpcAPI.PermissionsSubnetDump(ctx)
+	case "domain":
+		x, err = h.jsonrpcAPI.PermissionsDomainDump(ctx)
+	case "trusted":
+		x, err = h.jsonrpcAPI.PermissionsTrustedDump(ctx)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("table must be address, subnet, domain or trusted")
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) permissionsReload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	table := pat.Param(r, "table")
+	start := time.Now()
+	var err error
+	switch table {
+	case "address":
+		err = h.jsonrpcAPI.PermissionsAddressReload(ctx)
+	case "trusted":
+		err = h.jsonrpcAPI.PermissionsTrustedReload(ctx)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("table must be address or trusted")
+		return
+	}
+	h.audit(r, "permissions."+table+"Reload", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) permissionsCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	group, err := strconv.ParseInt(r.FormValue("group"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing or invalid group param")
+		return
+	}
+	ip := r.FormValue("ip")
+	if net.ParseIP(ip) == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing or invalid ip param")
+		return
+	}
+	port := int64(0)
+	if v := r.FormValue("port"); v != "" {
+		port, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("invalid port param")
+			return
+		}
+	}
+	x, err := h.jsonrpcAPI.PermissionsCheckAddress(ctx, group, ip, port)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) permissionsTestURI(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	basename := r.FormValue("basename")
+	uri := r.FormValue("uri")
+	contact := r.FormValue("contact")
+	if basename == "" || uri == "" || contact == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing basename, uri or contact param")
+		return
+	}
+	x, err := h.jsonrpcAPI.PermissionsTestURI(ctx, basename, uri, contact)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
@@ -0,0 +1,120 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"goji.io/pat"
+)
+
+// presenceEvent returns the event param, BLF uses dialog
+func presenceEvent(r *http.Request) string {
+	event := r.FormValue("event")
+	if event == "" {
+		return "dialog"
+	}
+	return event
+}
+
+func (h httpHandler) presenceList(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.PresencePresentityList(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+// presenceGet returns the presentities published for the uri and the
+// watchers subscribed to it
+func (h httpHandler) presenceGet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	uri := pat.Param(r, "uri")
+	type response struct {
+		URI          string                     `json:"uri"`
+		Event        string                     `json:"event"`
+		Presentities []jsonrpcc.Presentity      `json:"presentities"`
+		Watchers     []jsonrpcc.PresenceWatcher `json:"watchers"`
+	}
+	z := response{URI: uri, Event: presenceEvent(r)}
+	var err error
+	z.Presentities, err = h.jsonrpcAPI.PresencePresentityShow(ctx, uri)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	z.Watchers, err = h.jsonrpcAPI.PresenceWatcherList(ctx, uri, z.Event)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(z)
+}
+
+func (h httpHandler) presenceRefresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	uri := pat.Param(r, "uri")
+	event := presenceEvent(r)
+	refreshType := jsonrpcc.PresenceRefreshPresentity
+	if r.FormValue("type") == "auth" {
+		refreshType = jsonrpcc.PresenceRefreshAuthRules
+	}
+	start := time.Now()
+	err := h.jsonrpcAPI.PresenceRefreshWatchers(ctx, uri, event, refreshType)
+	h.audit(r, "presence.refresh_watchers", map[string]string{"uri": uri, "event": event, "type": strconv.Itoa(refreshType)}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) presencePublish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	z := jsonrpcc.PUAPublish{}
+	err := json.NewDecoder(r.Body).Decode(&z)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	z.PresentityURI = pat.Param(r, "uri")
+	if z.Event == "" || z.Expires < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("event is required and expires must not be negative")
+		return
+	}
+	if err := z.Validate(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	start := time.Now()
+	x, err := h.jsonrpcAPI.PUAPublish(ctx, z)
+	h.audit(r, "pua.publish", map[string]string{"uri": z.PresentityURI, "event": z.Event, "expires": strconv.Itoa(z.Expires)}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) presenceCleanup(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.PresenceCleanup(r.Context())
+	h.audit(r, "presence.cleanup", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,169 @@
+package serverhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"go.uber.org/zap"
+)
+
+// methodsRetryInterval is how long to wait before retrying the startup
+// method list when kamailio is not answering yet
+const methodsRetryInterval = 10 * time.Second
+
+// kamailioModule is a kamailio module a route depends on and the prefix of
+// the rpc methods it exports
+type kamailioModule struct {
+	name   string
+	prefix string
+}
+
+var (
+	moduleUAC          = kamailioModule{name: "uac", prefix: "uac."}
+	moduleCfgRPC       = kamailioModule{name: "cfg_rpc", prefix: "cfg."}
+	moduleHTable       = kamailioModule{name: "htable", prefix: "htable."}
+	moduleDispatcher   = kamailioModule{name: "dispatcher", prefix: "dispatcher."}
+	modulePermissions  = kamailioModule{name: "permissions", prefix: "permissions."}
+	moduleDialplan     = kamailioModule{name: "dialplan", prefix: "dialplan."}
+	moduleDrouting     = kamailioModule{name: "drouting", prefix: "drouting."}
+	moduleCarrierRoute = kamailioModule{name: "carrierroute", prefix: "cr."}
+	moduleLCR          = kamailioModule{name: "lcr", prefix: "lcr."}
+	modulePike         = kamailioModule{name: "pike", prefix: "pike."}
+	moduleSecfilter    = kamailioModule{name: "secfilter", prefix: "secfilter."}
+	moduleRTPEngine    = kamailioModule{name: "rtpengine", prefix: "rtpengine."}
+	moduleTM           = kamailioModule{name: "tm", prefix: "tm."}
+	moduleUsrloc       = kamailioModule{name: "usrloc", prefix: "ul."}
+	modulePresence     = kamailioModule{name: "presence", prefix: "presence."}
+	moduleCorex        = kamailioModule{name: "corex", prefix: "corex."}
+	moduleDomain       = kamailioModule{name: "domain", prefix: "domain."}
+	moduleTLS          = kamailioModule{name: "tls", prefix: "tls."}
+	moduleMtree        = kamailioModule{name: "mtree", prefix: "mtree."}
+	moduleSCA          = kamailioModule{name: "sca", prefix: "sca."}
+	moduleKex          = kamailioModule{name: "kex", prefix: "stats."}
+)
+
+// loadMethods fetches the kamailio method list at startup, retrying until
+// kamailio answers. Routes are not checked until it succeeds.
+func (h httpHandler) loadMethods(ctx context.Context) {
+	for {
+		x, err := h.jsonrpcAPI.RefreshMethods(ctx)
+		if err == nil {
+			h.logger.Info("loaded kamailio rpc methods", zap.Int("methods", len(x)))
+			return
+		}
+		h.logger.Warn("could not load kamailio rpc methods", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(methodsRetryInterval):
+		}
+	}
+}
+
+// requireModule checks perm, then returns 501 when module is not loaded.
+// The module is checked after perm so callers without it cannot learn which
+// modules are loaded.
+func (h httpHandler) requireModule(perm string, m kamailioModule, next http.HandlerFunc) http.HandlerFunc {
+	return h.auth.Require(perm, func(w http.ResponseWriter, r *http.Request) {
+		if found, known := h.jsonrpcAPI.HasMethodPrefix(m.prefix); known && !found {
+			w.WriteHeader(http.StatusNotImplemented)
+			json.NewEncoder(w).Encode("kamailio module " + m.name + " is not loaded, no " + m.prefix + "* rpc methods")
+			return
+		}
+		next(w, r)
+	})
+}
+
+// rpcMethods lists the kamailio rpc methods with their help text, loading
+// them first when no refresh has succeeded yet
+func (h httpHandler) rpcMethods(w http.ResponseWriter, r *http.Request) {
+	x, loadedAt := h.jsonrpcAPI.Methods()
+	if loadedAt.IsZero() {
+		var err error
+		x, err = h.jsonrpcAPI.RefreshMethods(r.Context())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+		_, loadedAt = h.jsonrpcAPI.Methods()
+	}
+	type response struct {
+		LoadedAt time.Time            `json:"loaded_at"`
+		Methods  []jsonrpcc.RPCMethod `json:"methods"`
+	}
+	z := response{LoadedAt: loadedAt, Methods: []jsonrpcc.RPCMethod{}}
+	prefix := r.FormValue("prefix")
+	for _, m := range x {
+		if strings.HasPrefix(m.Name, prefix) {
+			z.Methods = append(z.Methods, m)
+		}
+	}
+	json.NewEncoder(w).Encode(z)
+}
+
+func (h httpHandler) rpcRefresh(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.RefreshMethods(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]int{"methods": len(x)})
+}
+
+// rpcBatch sends the calls of the body as one jsonrpc batch and returns the
+// result or error of each call in order
+func (h httpHandler) rpcBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	calls := []jsonrpcc.Call{}
+	err := json.NewDecoder(r.Body).Decode(&calls)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if len(calls) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("no calls")
+		return
+	}
+	methods := []string{}
+	for _, c := range calls {
+		if c.Method == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("every call needs a method")
+			return
+		}
+		methods = append(methods, c.Method)
+	}
+	slices.Sort(methods)
+	params := map[string]string{"calls": strconv.Itoa(len(calls)), "methods": strings.Join(slices.Compact(methods), ",")}
+	start := time.Now()
+	x, err := h.jsonrpcAPI.Batch(ctx, calls...)
+	h.audit(r, "rpc.batch", params, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	type result struct {
+		Method string          `json:"method"`
+		Result json.RawMessage `json:"result,omitempty"`
+		Error  string          `json:"error,omitempty"`
+	}
+	z := make([]result, len(x))
+	for i, v := range x {
+		z[i] = result{Method: calls[i].Method, Result: v.Result}
+		if v.Err != nil {
+			z[i].Error = v.Err.Error()
+		}
+	}
+	json.NewEncoder(w).Encode(z)
+}
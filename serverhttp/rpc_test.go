@@ -0,0 +1,64 @@
+package serverhttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+)
+
+func TestRequireModule(t *testing.T) {
+	k := fakeKamailio(t, map[string]interface{}{
+		"system.listMethods": []string{"core.version", "system.listMethods"},
+	})
+	s := newTestServer(t, k.URL, config.Auth{
+		Enabled: true,
+		APIKeys: []config.APIKey{
+			{Name: "viewer", Key: "viewer-key", Roles: []string{"viewer"}},
+			{Name: "ops", Key: "ops-key", Roles: []string{"ops"}},
+		},
+		Roles: map[string][]string{
+			"viewer": {"core:read"},
+			"ops":    {"dialplan:read"},
+		},
+	})
+	defer s.Shutdown(context.Background())
+	url := "http://" + s.Addr().String() + "/v1/dialplan/1"
+	get := func(key string) int {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("X-API-Key", key)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	// wait for the startup method list
+	deadline := time.Now().Add(5 * time.Second)
+	for get("ops-key") != http.StatusNotImplemented {
+		if time.Now().After(deadline) {
+			t.Fatal("missing dialplan module never returned 501")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{key: "ops-key", want: http.StatusNotImplemented},
+		// without the permission the module state is not revealed
+		{key: "viewer-key", want: http.StatusForbidden},
+		{key: "wrong-key", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		if got := get(tt.key); got != tt.want {
+			t.Fatalf("GET with %s = %d, want %d", tt.key, got, tt.want)
+		}
+	}
+}
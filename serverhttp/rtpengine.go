@@ -0,0 +1,107 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+)
+
+// rtpengineURL returns the url param, rtpengine node urls contain ':' and
+// '/' so they are passed as a query param rather than a path segment
+func rtpengineURL(r *http.Request) string {
+	url := r.FormValue("url")
+	if url == "" {
+		return jsonrpcc.RTPEngineAll
+	}
+	return url
+}
+
+func (h httpHandler) rtpengineShow(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	x, err := h.jsonrpcAPI.RTPEngineShow(r.Context(), rtpengineURL(r))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) rtpenginePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	action := r.FormValue("action")
+	if action != "enable" && action != "disable" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("action must be enable or disable")
+		return
+	}
+	url := r.FormValue("url")
+	if url == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing url param, use url=all for every node")
+		return
+	}
+	start := time.Now()
+	err = h.jsonrpcAPI.RTPEngineEnable(ctx, url, action == "enable")
+	h.audit(r, "rtpengine.enable", map[string]string{"url": url, "action": action}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) rtpenginePing(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	url := rtpengineURL(r)
+	start := time.Now()
+	x, err := h.jsonrpcAPI.RTPEnginePing(r.Context(), url)
+	h.audit(r, "rtpengine.ping", map[string]string{"url": url}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) rtpengineReload(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.RTPEngineReload(r.Context())
+	h.audit(r, "rtpengine.reload", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) rtpengineHashTotal(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.RTPEngineHashTotal(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]int64{"total": x})
+}
@@ -0,0 +1,37 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"goji.io/pat"
+)
+
+func (h httpHandler) scaSubscriptions(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.SCAAllSubscriptions(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) scaSubscription(w http.ResponseWriter, r *http.Request) {
+	event := r.FormValue("event")
+	if event == "" {
+		event = "call-info"
+	}
+	if event != "call-info" && event != "line-seize" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("event must be call-info or line-seize")
+		return
+	}
+	x, err := h.jsonrpcAPI.SCAShowSubscription(r.Context(), event, pat.Param(r, "aor"))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
@@ -0,0 +1,109 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"goji.io/pat"
+)
+
+func (h httpHandler) securityTop(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	filter := strings.ToUpper(r.FormValue("filter"))
+	if filter != "" && filter != "ALL" && filter != "HOT" && filter != "WARM" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("filter must be all, hot or warm")
+		return
+	}
+	x, err := h.jsonrpcAPI.PikeTop(r.Context(), filter)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) secfilterPrint(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.SecfilterPrint(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) secfilterReload(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.SecfilterReload(r.Context())
+	h.audit(r, "secfilter.reload", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// secfilterList adds (POST) or removes (DELETE) an entry of the blacklist or
+// whitelist named by the list param
+func (h httpHandler) secfilterList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list := pat.Param(r, "list")
+	if list != "blacklist" && list != "whitelist" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("list must be blacklist or whitelist")
+		return
+	}
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("bad request")
+		return
+	}
+	listType := r.FormValue("type")
+	value := r.FormValue("value")
+	if listType == "" || value == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("missing type or value param")
+		return
+	}
+	if !slices.Contains(jsonrpcc.SecfilterTypes, listType) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("type must be one of " + strings.Join(jsonrpcc.SecfilterTypes, ", "))
+		return
+	}
+	operation := ""
+	start := time.Now()
+	switch {
+	case r.Method == http.MethodPost && list == "blacklist":
+		operation = "secfilter.add_bl"
+		err = h.jsonrpcAPI.SecfilterAddBlacklist(ctx, listType, value)
+	case r.Method == http.MethodPost:
+		operation = "secfilter.add_wl"
+		err = h.jsonrpcAPI.SecfilterAddWhitelist(ctx, listType, value)
+	case list == "blacklist":
+		operation = "secfilter.del_bl"
+		err = h.jsonrpcAPI.SecfilterDelBlacklist(ctx, listType, value)
+	default:
+		operation = "secfilter.del_wl"
+		err = h.jsonrpcAPI.SecfilterDelWhitelist(ctx, listType, value)
+	}
+	h.audit(r, operation, map[string]string{"type": listType, "value": value}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,8 +1,16 @@
 package serverhttp
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/audit"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/auth"
 	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
 	"go.uber.org/zap"
 	"goji.io"
@@ -13,42 +21,358 @@ const (
 	requestPath = "/v1/*"
 )
 
+// Options tunes the http.Server behind the REST API
+type Options struct {
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	MaxBodyBytes int64
+	TLSConfig    *tls.Config
+	// ReadyTimeout bounds the kamailio probe behind /readyz and
+	// ReadyCacheTTL is how long its result is reused
+	ReadyTimeout  time.Duration
+	ReadyCacheTTL time.Duration
+	// IPBanTable is the htable behind /v1/bans
+	IPBanTable string
+	// UsrlocTable is the usrloc domain looked up by /v1/devices
+	UsrlocTable string
+}
+
 type httpHandler struct {
-	listenAddr string
-	jsonrpcAPI jsonrpcc.API
-	logger     *zap.Logger
+	listenAddr  string
+	jsonrpcAPI  jsonrpcc.API
+	auth        *auth.Auth
+	auditLog    *audit.Log
+	server      *Server
+	ready       *readiness
+	debug       *debugEscalation
+	ipBanTable  string
+	usrlocTable string
+	logger      *zap.Logger
+}
+
+// Server is the REST API. It owns the http.Server and every background
+// worker started through Go so both can be drained on Shutdown.
+type Server struct {
+	srv    *http.Server
+	errc   chan error
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	addr   net.Addr
+	logger *zap.Logger
 }
 
-func ListenAndServe(listenAddr string, jsonrpcAPI jsonrpcc.API, logger *zap.Logger) error {
+func New(listenAddr string, o Options, jsonrpcAPI jsonrpcc.API, a *auth.Auth, auditLog *audit.Log, logger *zap.Logger) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		errc:   make(chan error, 1),
+		ctx:    ctx,
+		cancel: cancel,
+		logger: logger,
+	}
 	root := goji.NewMux()
+	root.Use(maxBodyBytes(o.MaxBodyBytes))
 	v := goji.SubMux()
 	h := httpHandler{
-		listenAddr: listenAddr,
-		jsonrpcAPI: jsonrpcAPI,
-		logger:     logger,
+		listenAddr:  listenAddr,
+		jsonrpcAPI:  jsonrpcAPI,
+		auth:        a,
+		auditLog:    auditLog,
+		server:      s,
+		ready:       &readiness{timeout: o.ReadyTimeout, ttl: o.ReadyCacheTTL},
+		debug:       &debugEscalation{},
+		ipBanTable:  o.IPBanTable,
+		usrlocTable: o.UsrlocTable,
+		logger:      logger,
 	}
+	v.Use(a.Middleware)
+	// GET /healthz returns 200 while the process is serving
+	root.HandleFunc(pat.Get("/healthz"), h.healthz)
+	// GET /readyz returns 200 when kamailio answers core.version and core.uptime, 503 otherwise
+	root.HandleFunc(pat.Get("/readyz"), h.readyz)
 	root.Handle(pat.New(requestPath), v)
 	// POST /v1/uacreg/register returns 200
-	v.HandleFunc(pat.Post("/uacreg/register"), h.uacRegister)
+	v.HandleFunc(pat.Post("/uacreg/register"), h.requireModule("uacreg:write", moduleUAC, h.uacRegister))
 	// POST /v1/uacreg/unregister?domain=test.com&username=1000  returns 200
-	v.HandleFunc(pat.Post("/uacreg/unregister"), h.uacUnregister)
+	v.HandleFunc(pat.Post("/uacreg/unregister"), h.requireModule("uacreg:write", moduleUAC, h.uacUnregister))
 	// GET /v1/uacreg/list?domain=test.com&username=1000 returns 200
-	v.HandleFunc(pat.Get("/uacreg/list"), h.uacList)
+	v.HandleFunc(pat.Get("/uacreg/list"), h.requireModule("uacreg:read", moduleUAC, h.uacList))
 	// GET /v1/htable/dump?table=mytable returns 200
-	v.HandleFunc(pat.Get("/htable/dump"), h.htableDump)
+	v.HandleFunc(pat.Get("/htable/dump"), h.requireModule("htable:read", moduleHTable, h.htableDump))
 	// GET /v1/htable/mytable?key=myKey returns 200
-	v.HandleFunc(pat.Get("/htable/:table"), h.htableGet)
+	v.HandleFunc(pat.Get("/htable/:table"), h.requireModule("htable:read", moduleHTable, h.htableGet))
 	// POST /v1/htable/mytable?action=flush returns 204
-	v.HandleFunc(pat.Post("/htable/:table"), h.htablePost)
+	v.HandleFunc(pat.Post("/htable/:table"), h.requireModule("htable:write", moduleHTable, h.htablePost))
 	// DELETE /v1/htable/mytable/mykey returns 204
-	v.HandleFunc(pat.Delete("/htable/:table/:key"), h.htableDelete)
+	v.HandleFunc(pat.Delete("/htable/:table/:key"), h.requireModule("htable:write", moduleHTable, h.htableDelete))
 	// DELETE /v1/htable/mytable?name_contains=mykey&value_contains=myvalue returns 204
-	v.HandleFunc(pat.Delete("/htable/:table"), h.htableDeleteQuery)
+	v.HandleFunc(pat.Delete("/htable/:table"), h.requireModule("htable:write", moduleHTable, h.htableDeleteQuery))
 	// GET /v1/dispatcher/list?rmode=short returns 200
-	v.HandleFunc(pat.Get("/dispatcher/list"), h.dispatcherList)
+	v.HandleFunc(pat.Get("/dispatcher/list"), h.requireModule("dispatcher:read", moduleDispatcher, h.dispatcherList))
 	// POST /v1/dispatcher/[group]?addr=sip:10.0.0.1:5060 returns 204
-	v.HandleFunc(pat.Post("/dispatcher/:group"), h.dispatcherAdd)
+	v.HandleFunc(pat.Post("/dispatcher/:group"), h.requireModule("dispatcher:admin", moduleDispatcher, h.dispatcherAdd))
 	// DELETE /v1/dispatcher/[group]?addr=sip:10.0.0.1:5060 returns 204
-	v.HandleFunc(pat.Delete("/dispatcher/:group"), h.dispatcherRemove)
-	return http.ListenAndServe(listenAddr, root)
+	v.HandleFunc(pat.Delete("/dispatcher/:group"), h.requireModule("dispatcher:admin", moduleDispatcher, h.dispatcherRemove))
+	// GET /v1/core returns 200 with info, uptime and loaded modules
+	v.HandleFunc(pat.Get("/core"), a.Require("core:read", h.coreSummary))
+	// GET /v1/core/version returns 200
+	v.HandleFunc(pat.Get("/core/version"), a.Require("core:read", h.coreVersion))
+	// GET /v1/core/uptime returns 200
+	v.HandleFunc(pat.Get("/core/uptime"), a.Require("core:read", h.coreUptime))
+	// GET /v1/core/info returns 200
+	v.HandleFunc(pat.Get("/core/info"), a.Require("core:read", h.coreInfo))
+	// GET /v1/core/processes returns 200
+	v.HandleFunc(pat.Get("/core/processes"), a.Require("core:read", h.coreProcesses))
+	// GET /v1/core/shmmem?unit=m returns 200
+	v.HandleFunc(pat.Get("/core/shmmem"), a.Require("core:read", h.coreShmMem))
+	// GET /v1/core/tcp returns 200
+	v.HandleFunc(pat.Get("/core/tcp"), a.Require("core:read", h.coreTCPInfo))
+	// GET /v1/core/sockets returns 200
+	v.HandleFunc(pat.Get("/core/sockets"), a.Require("core:read", h.coreSockets))
+	// GET /v1/core/modules returns 200
+	v.HandleFunc(pat.Get("/core/modules"), a.Require("core:read", h.coreModules))
+	// GET /v1/cfg returns 200 with every cfg group and var
+	v.HandleFunc(pat.Get("/cfg"), h.requireModule("cfg:read", moduleCfgRPC, h.cfgList))
+	// POST /v1/cfg {"changes":[{"group":"core","var":"debug","value":3}]} sets delayed and commits, returns 204
+	v.HandleFunc(pat.Post("/cfg"), h.requireModule("cfg:write", moduleCfgRPC, h.cfgApply))
+	// GET /v1/cfg/diff returns 200 with uncommitted delayed changes
+	v.HandleFunc(pat.Get("/cfg/diff"), h.requireModule("cfg:read", moduleCfgRPC, h.cfgDiff))
+	// POST /v1/cfg/commit returns 204
+	v.HandleFunc(pat.Post("/cfg/commit"), h.requireModule("cfg:write", moduleCfgRPC, h.cfgCommit))
+	// POST /v1/cfg/rollback returns 204
+	v.HandleFunc(pat.Post("/cfg/rollback"), h.requireModule("cfg:write", moduleCfgRPC, h.cfgRollback))
+	// GET /v1/cfg/core/debug returns 200
+	v.HandleFunc(pat.Get("/cfg/:group/:var"), h.requireModule("cfg:read", moduleCfgRPC, h.cfgGet))
+	// PUT /v1/cfg/core/debug?delayed=true {"value":3} returns 204
+	v.HandleFunc(pat.Put("/cfg/:group/:var"), h.requireModule("cfg:write", moduleCfgRPC, h.cfgPut))
+	// GET /v1/debug returns 200 with the pending debug level revert, 404 when none
+	v.HandleFunc(pat.Get("/debug"), h.requireModule("cfg:read", moduleCfgRPC, h.debugGet))
+	// POST /v1/debug?level=3&duration=10m raises core.debug and reverts it after duration, returns 200
+	v.HandleFunc(pat.Post("/debug"), h.requireModule("cfg:write", moduleCfgRPC, h.debugSet))
+	// DELETE /v1/debug reverts now, with ?revert=false keeps the level and drops the revert, returns 204
+	v.HandleFunc(pat.Delete("/debug"), h.requireModule("cfg:write", moduleCfgRPC, h.debugCancel))
+	// GET /v1/permissions/check?group=1&ip=10.0.0.1&port=5060 returns 200
+	v.HandleFunc(pat.Get("/permissions/check"), h.requireModule("permissions:read", modulePermissions, h.permissionsCheck))
+	// GET /v1/permissions/test_uri?basename=register&uri=sip:1000@test.com&contact=sip:1000@10.0.0.1 returns 200
+	v.HandleFunc(pat.Get("/permissions/test_uri"), h.requireModule("permissions:read", modulePermissions, h.permissionsTestURI))
+	// GET /v1/permissions/[address|subnet|domain|trusted] returns 200
+	v.HandleFunc(pat.Get("/permissions/:table"), h.requireModule("permissions:read", modulePermissions, h.permissionsDump))
+	// POST /v1/permissions/[address|trusted]/reload returns 204
+	v.HandleFunc(pat.Post("/permissions/:table/reload"), h.requireModule("permissions:write", modulePermissions, h.permissionsReload))
+	// POST /v1/dialplan/reload returns 204
+	v.HandleFunc(pat.Post("/dialplan/reload"), h.requireModule("dialplan:write", moduleDialplan, h.dialplanReload))
+	// GET /v1/dialplan/1 returns 200
+	v.HandleFunc(pat.Get("/dialplan/:dpid"), h.requireModule("dialplan:read", moduleDialplan, h.dialplanDump))
+	// POST /v1/dialplan/1/translate {"input":"0044123456"} returns 200 with output and matched rule
+	v.HandleFunc(pat.Post("/dialplan/:dpid/translate"), h.requireModule("dialplan:read", moduleDialplan, h.dialplanTranslate))
+	// GET /v1/drouting returns 200 with gateways and rules
+	v.HandleFunc(pat.Get("/drouting"), h.requireModule("drouting:read", moduleDrouting, h.droutingDump))
+	// POST /v1/drouting/reload returns 204
+	v.HandleFunc(pat.Post("/drouting/reload"), h.requireModule("drouting:write", moduleDrouting, h.droutingReload))
+	// GET /v1/drouting/gateways returns 200
+	v.HandleFunc(pat.Get("/drouting/gateways"), h.requireModule("drouting:read", moduleDrouting, h.droutingGateways))
+	// GET /v1/drouting/gateways/gw1 returns 200
+	v.HandleFunc(pat.Get("/drouting/gateways/:id"), h.requireModule("drouting:read", moduleDrouting, h.droutingGateway))
+	// POST /v1/drouting/gateways/gw1?action=disable returns 204
+	v.HandleFunc(pat.Post("/drouting/gateways/:id"), h.requireModule("drouting:write", moduleDrouting, h.droutingGatewayPost))
+	// GET /v1/drouting/rules returns 200
+	v.HandleFunc(pat.Get("/drouting/rules"), h.requireModule("drouting:read", moduleDrouting, h.droutingRules))
+	// POST /v1/drouting/rules/10?action=enable returns 204
+	v.HandleFunc(pat.Post("/drouting/rules/:id"), h.requireModule("drouting:write", moduleDrouting, h.droutingRulePost))
+	// GET /v1/carrierroute returns 200 with the routing tree
+	v.HandleFunc(pat.Get("/carrierroute"), h.requireModule("carrierroute:read", moduleCarrierRoute, h.carrierRouteDump))
+	// POST /v1/carrierroute/reload returns 204
+	v.HandleFunc(pat.Post("/carrierroute/reload"), h.requireModule("carrierroute:write", moduleCarrierRoute, h.carrierRouteReload))
+	// POST /v1/carrierroute/hosts/[activate|deactivate] {"carrier":"default","domain":"proxy","prefix":"49","host":"10.0.0.1"} returns 204
+	v.HandleFunc(pat.Post("/carrierroute/hosts/:action"), h.requireModule("carrierroute:write", moduleCarrierRoute, h.carrierRouteHost))
+	// GET /v1/lcr/gateways returns 200
+	v.HandleFunc(pat.Get("/lcr/gateways"), h.requireModule("lcr:read", moduleLCR, h.lcrGateways))
+	// GET /v1/lcr/rules returns 200
+	v.HandleFunc(pat.Get("/lcr/rules"), h.requireModule("lcr:read", moduleLCR, h.lcrRules))
+	// POST /v1/lcr/reload returns 204
+	v.HandleFunc(pat.Post("/lcr/reload"), h.requireModule("lcr:write", moduleLCR, h.lcrReload))
+	// POST /v1/lcr/gateways/1/3/defunct?period=600 returns 204
+	v.HandleFunc(pat.Post("/lcr/gateways/:lcr_id/:gw_id/defunct"), h.requireModule("lcr:write", moduleLCR, h.lcrDefunctGateway))
+	// GET /v1/security/top?filter=hot returns 200 with the top pike offenders
+	v.HandleFunc(pat.Get("/security/top"), h.requireModule("security:read", modulePike, h.securityTop))
+	// GET /v1/security/secfilter returns 200 with the loaded secfilter lists
+	v.HandleFunc(pat.Get("/security/secfilter"), h.requireModule("security:read", moduleSecfilter, h.secfilterPrint))
+	// POST /v1/security/secfilter/reload returns 204
+	v.HandleFunc(pat.Post("/security/secfilter/reload"), h.requireModule("security:write", moduleSecfilter, h.secfilterReload))
+	// POST /v1/security/[blacklist|whitelist]?type=ip&value=10.0.0.1 returns 204
+	v.HandleFunc(pat.Post("/security/:list"), h.requireModule("security:write", moduleSecfilter, h.secfilterList))
+	// DELETE /v1/security/[blacklist|whitelist]?type=ip&value=10.0.0.1 returns 204
+	v.HandleFunc(pat.Delete("/security/:list"), h.requireModule("security:write", moduleSecfilter, h.secfilterList))
+	// GET /v1/bans returns 200 with active bans and their remaining time
+	v.HandleFunc(pat.Get("/bans"), h.requireModule("bans:read", moduleHTable, h.bansList))
+	// POST /v1/bans {"ip":"203.0.113.7","reason":"flood","ttl":"1h"} returns 204
+	v.HandleFunc(pat.Post("/bans"), h.requireModule("bans:write", moduleHTable, h.bansAdd))
+	// POST /v1/bans/import {"cidrs":["203.0.113.0/24"],"reason":"flood","ttl":"24h"} returns 200
+	v.HandleFunc(pat.Post("/bans/import"), h.requireModule("bans:write", moduleHTable, h.bansImport))
+	// DELETE /v1/bans/203.0.113.7 returns 204
+	v.HandleFunc(pat.Delete("/bans/:ip"), h.requireModule("bans:write", moduleHTable, h.bansDelete))
+	// GET /v1/rtpengine?url=udp:10.0.0.1:22222 returns 200, every node when url is omitted
+	v.HandleFunc(pat.Get("/rtpengine"), h.requireModule("rtpengine:read", moduleRTPEngine, h.rtpengineShow))
+	// POST /v1/rtpengine?action=disable&url=udp:10.0.0.1:22222 returns 204
+	v.HandleFunc(pat.Post("/rtpengine"), h.requireModule("rtpengine:write", moduleRTPEngine, h.rtpenginePost))
+	// POST /v1/rtpengine/ping?url=all returns 200, a node that answers is enabled again
+	v.HandleFunc(pat.Post("/rtpengine/ping"), h.requireModule("rtpengine:write", moduleRTPEngine, h.rtpenginePing))
+	// POST /v1/rtpengine/reload returns 204
+	v.HandleFunc(pat.Post("/rtpengine/reload"), h.requireModule("rtpengine:write", moduleRTPEngine, h.rtpengineReload))
+	// GET /v1/rtpengine/hash_total returns 200
+	v.HandleFunc(pat.Get("/rtpengine/hash_total"), h.requireModule("rtpengine:read", moduleRTPEngine, h.rtpengineHashTotal))
+	// GET /v1/tm/stats returns 200
+	v.HandleFunc(pat.Get("/tm/stats"), h.requireModule("tm:read", moduleTM, h.tmStats))
+	// GET /v1/tm/hash_stats returns 200
+	v.HandleFunc(pat.Get("/tm/hash_stats"), h.requireModule("tm:read", moduleTM, h.tmHashStats))
+	// GET /v1/tm/transactions?callid=abc&method=INVITE returns 200
+	v.HandleFunc(pat.Get("/tm/transactions"), h.requireModule("tm:read", moduleTM, h.tmTransactions))
+	// POST /v1/tm/cancel {"callid":"abc","cseq":"1"} returns 204
+	v.HandleFunc(pat.Post("/tm/cancel"), h.requireModule("tm:write", moduleTM, h.tmCancel))
+	// POST /v1/tm/reply {"code":480,"reason":"Unavailable","trans_id":"1234:5678"} returns 204
+	v.HandleFunc(pat.Post("/tm/reply"), h.requireModule("tm:write", moduleTM, h.tmReply))
+	// POST /v1/sip/send {"method":"OPTIONS","ruri":"sip:10.0.0.1","wait":true} returns 200, 202 without wait
+	v.HandleFunc(pat.Post("/sip/send"), h.requireModule("sip:write", moduleTM, h.sipSend))
+	// GET /v1/devices/100@example.com returns 200 with registered contacts
+	v.HandleFunc(pat.Get("/devices/:aor"), h.requireModule("devices:read", moduleUsrloc, h.deviceContacts))
+	// POST /v1/devices/100@example.com/check-sync?reboot=true returns 200 with the reply of each contact
+	v.HandleFunc(pat.Post("/devices/:aor/check-sync"), h.requireModule("devices:write", moduleUsrloc, h.deviceCheckSync))
+	// GET /v1/presence returns 200 with every presentity
+	v.HandleFunc(pat.Get("/presence"), h.requireModule("presence:read", modulePresence, h.presenceList))
+	// POST /v1/presence/cleanup returns 204
+	v.HandleFunc(pat.Post("/presence/cleanup"), h.requireModule("presence:write", modulePresence, h.presenceCleanup))
+	// GET /v1/presence/sip:100@example.com?event=dialog returns 200 with presentities and watchers
+	v.HandleFunc(pat.Get("/presence/:uri"), h.requireModule("presence:read", modulePresence, h.presenceGet))
+	// POST /v1/presence/sip:100@example.com/refresh?event=dialog returns 204
+	v.HandleFunc(pat.Post("/presence/:uri/refresh"), h.requireModule("presence:write", modulePresence, h.presenceRefresh))
+	// POST /v1/presence/sip:100@example.com/publish {"event":"dialog","expires":3600,"content_type":"application/dialog-info+xml","body":"..."} returns 200
+	v.HandleFunc(pat.Post("/presence/:uri/publish"), h.requireModule("presence:write", modulePresence, h.presencePublish))
+	// GET /v1/domains returns 200 with domains and their attributes
+	v.HandleFunc(pat.Get("/domains"), h.requireModule("domains:read", moduleDomain, h.domainList))
+	// GET /v1/domains/aliases returns 200 with the core aliases
+	v.HandleFunc(pat.Get("/domains/aliases"), h.requireModule("domains:read", moduleCorex, h.domainAliases))
+	// POST /v1/domains/reload returns 204
+	v.HandleFunc(pat.Post("/domains/reload"), h.requireModule("domains:write", moduleDomain, h.domainReload))
+	// GET /v1/domains/example.com returns 200
+	v.HandleFunc(pat.Get("/domains/:domain"), h.requireModule("domains:read", moduleDomain, h.domainGet))
+	// GET /v1/tls returns 200 with connection counters
+	v.HandleFunc(pat.Get("/tls"), h.requireModule("tls:read", moduleTLS, h.tlsInfo))
+	// GET /v1/tls/connections returns 200
+	v.HandleFunc(pat.Get("/tls/connections"), h.requireModule("tls:read", moduleTLS, h.tlsConnections))
+	// GET /v1/tls/options returns 200
+	v.HandleFunc(pat.Get("/tls/options"), h.requireModule("tls:read", moduleTLS, h.tlsOptions))
+	// POST /v1/tls/reload returns 204
+	v.HandleFunc(pat.Post("/tls/reload"), h.requireModule("tls:write", moduleTLS, h.tlsReload))
+	// GET /v1/mtree?table=routes returns 200, every tree when table is omitted
+	v.HandleFunc(pat.Get("/mtree"), h.requireModule("mtree:read", moduleMtree, h.mtreeList))
+	// POST /v1/mtree/reload?table=routes returns 204, every tree when table is omitted
+	v.HandleFunc(pat.Post("/mtree/reload"), h.requireModule("mtree:write", moduleMtree, h.mtreeReload))
+	// GET /v1/mtree/routes/match?prefix=4420 returns 200
+	v.HandleFunc(pat.Get("/mtree/:table/match"), h.requireModule("mtree:read", moduleMtree, h.mtreeMatch))
+	// GET /v1/sca/subscriptions returns 200
+	v.HandleFunc(pat.Get("/sca/subscriptions"), h.requireModule("sca:read", moduleSCA, h.scaSubscriptions))
+	// GET /v1/sca/subscriptions/sip:100@example.com?event=line-seize returns 200
+	v.HandleFunc(pat.Get("/sca/subscriptions/:aor"), h.requireModule("sca:read", moduleSCA, h.scaSubscription))
+	// GET /v1/topos/stats returns 200
+	v.HandleFunc(pat.Get("/topos/stats"), h.requireModule("topos:read", moduleKex, h.toposStats))
+	// GET /v1/rpc?prefix=tm. returns 200 with kamailio rpc methods and their help text
+	v.HandleFunc(pat.Get("/rpc"), a.Require("rpc:read", h.rpcMethods))
+	// POST /v1/rpc/refresh reloads the rpc methods, returns 200
+	v.HandleFunc(pat.Post("/rpc/refresh"), a.Require("rpc:read", h.rpcRefresh))
+	// POST /v1/rpc/batch [{"method":"core.version"},{"method":"htable.get","params":["t","k"]}] returns 200
+	v.HandleFunc(pat.Post("/rpc/batch"), a.Require("rpc:admin", h.rpcBatch))
+	// GET /v1/audit?identity=ops&operation=htable.flush&since=2024-01-01T00:00:00Z&limit=100 returns 200
+	v.HandleFunc(pat.Get("/audit"), a.Require("audit:read", h.auditList))
+	s.srv = &http.Server{
+		Addr:              listenAddr,
+		Handler:           root,
+		TLSConfig:         o.TLSConfig,
+		ReadTimeout:       o.ReadTimeout,
+		ReadHeaderTimeout: o.ReadTimeout,
+		WriteTimeout:      o.WriteTimeout,
+		IdleTimeout:       o.IdleTimeout,
+		ErrorLog:          zap.NewStdLog(logger),
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	s.Go(h.loadMethods)
+	return s
+}
+
+// Start binds the listen address and serves in the background, over TLS when
+// Options.TLSConfig was set. Errors after a successful bind are sent on Err.
+func (s *Server) Start() error {
+	l, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return err
+	}
+	s.addr = l.Addr()
+	if s.srv.TLSConfig != nil {
+		l = tls.NewListener(l, s.srv.TLSConfig)
+		s.logger.Info("serving https", zap.String("listen_addr", s.srv.Addr))
+	} else {
+		s.logger.Info("serving http", zap.String("listen_addr", s.srv.Addr))
+	}
+	go func() {
+		if err := s.srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.errc <- err
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address bound by Start, which differs from the listen
+// address when that uses port 0
+func (s *Server) Addr() net.Addr {
+	return s.addr
+}
+
+// Err returns a channel that receives the error if serving stops unexpectedly
+func (s *Server) Err() <-chan error {
+	return s.errc
+}
+
+// Go runs fn as a background worker. The context passed to fn is cancelled
+// when Shutdown is called, and Shutdown waits for fn to return.
+func (s *Server) Go(fn func(ctx context.Context)) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		fn(s.ctx)
+	}()
+}
+
+// Shutdown stops accepting requests, waits for in-flight requests and their
+// kamailio calls to finish, then stops background workers. When ctx expires
+// first the remaining connections are closed and ctx.Err is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("shutting down http server")
+	err := s.srv.Shutdown(ctx)
+	if err != nil {
+		s.srv.Close()
+	}
+	s.cancel()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return err
+}
+
+// maxBodyBytes limits how much of a request body handlers may read
+func maxBodyBytes(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if n > 0 && r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, n)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
 }
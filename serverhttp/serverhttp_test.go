@@ -0,0 +1,153 @@
+package serverhttp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/audit"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/auth"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/config"
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+	"go.uber.org/zap"
+)
+
+// fakeKamailio answers jsonrpc requests and batches with the result set for
+// their method, a func(params) result is called for every request
+func fakeKamailio(t *testing.T, results map[string]interface{}) *httptest.Server {
+	t.Helper()
+	type request struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+		ID     json.RawMessage   `json:"id"`
+	}
+	reply := func(req request) map[string]interface{} {
+		x, ok := results[req.Method]
+		if !ok {
+			return map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": map[string]interface{}{"code": -32601, "message": "Method Not Found"}}
+		}
+		if fn, isFunc := x.(func([]json.RawMessage) interface{}); isFunc {
+			x = fn(req.Params)
+		}
+		return map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": x}
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batch := []request{}
+		if err := json.Unmarshal(b, &batch); err == nil {
+			z := []map[string]interface{}{}
+			for _, req := range batch {
+				z = append(z, reply(req))
+			}
+			json.NewEncoder(w).Encode(z)
+			return
+		}
+		req := request{}
+		if err := json.Unmarshal(b, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(reply(req))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newTestServer starts a Server on a free local port
+func newTestServer(t *testing.T, kamailioURL string, c config.Auth) *Server {
+	t.Helper()
+	l := zap.NewNop()
+	j, err := jsonrpcc.New(kamailioURL, jsonrpcc.Options{Timeout: 5 * time.Second}, l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := auth.New(c, l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	al, err := audit.New(config.Audit{}, l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New("127.0.0.1:0", Options{ReadyTimeout: time.Second}, j, a, al, l)
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestServerStartShutdown(t *testing.T) {
+	k := fakeKamailio(t, map[string]interface{}{
+		"system.listMethods": []string{"core.version", "system.listMethods"},
+		"core.version":       "kamailio 5.8.0 (x86_64/linux)",
+	})
+	s := newTestServer(t, k.URL, config.Auth{})
+	base := "http://" + s.Addr().String()
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "/healthz", want: http.StatusOK},
+		{path: "/v1/core/version", want: http.StatusOK},
+		{path: "/v1/unknown", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(base + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Fatalf("GET %s = %d, want %d", tt.path, resp.StatusCode, tt.want)
+		}
+	}
+
+	worker := make(chan struct{})
+	s.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		close(worker)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-worker:
+	default:
+		t.Fatal("Shutdown returned before the background worker")
+	}
+	if _, err := http.Get(base + "/healthz"); err == nil {
+		t.Fatal("server still serving after Shutdown")
+	}
+	select {
+	case err := <-s.Err():
+		t.Fatalf("unexpected serve error: %v", err)
+	default:
+	}
+}
+
+func TestServerStartAddressInUse(t *testing.T) {
+	k := fakeKamailio(t, map[string]interface{}{})
+	s := newTestServer(t, k.URL, config.Auth{})
+	defer s.Shutdown(context.Background())
+	l := zap.NewNop()
+	j, _ := jsonrpcc.New(k.URL, jsonrpcc.Options{}, l)
+	a, _ := auth.New(config.Auth{}, l)
+	al, _ := audit.New(config.Audit{}, l)
+	x := New(s.Addr().String(), Options{}, j, a, al, l)
+	defer x.Shutdown(context.Background())
+	if err := x.Start(); err == nil {
+		t.Fatal("Start on a bound address succeeded")
+	}
+}
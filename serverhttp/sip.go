@@ -0,0 +1,59 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+)
+
+var sipMethod = regexp.MustCompile(`^[A-Z]+$`)
+
+func (h httpHandler) sipSend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	type request struct {
+		jsonrpcc.SIPRequest
+		// Wait blocks until the final reply and returns it
+		Wait bool `json:"wait"`
+	}
+	z := request{}
+	err := json.NewDecoder(r.Body).Decode(&z)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if !sipMethod.MatchString(z.Method) || z.RURI == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("method and ruri are required")
+		return
+	}
+	if err := z.Validate(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	params := map[string]string{"method": z.Method, "ruri": z.RURI, "outbound_proxy": z.OutboundProxy}
+	start := time.Now()
+	if !z.Wait {
+		err = h.jsonrpcAPI.SIPSend(ctx, z.SIPRequest)
+		h.audit(r, "sip.send", params, start, err)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	x, err := h.jsonrpcAPI.SIPSendWait(ctx, z.SIPRequest)
+	h.audit(r, "sip.send", params, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
@@ -0,0 +1,49 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func (h httpHandler) tlsInfo(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.TLSInfo(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) tlsConnections(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.TLSConnections(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) tlsOptions(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.TLSOptions(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) tlsReload(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	err := h.jsonrpcAPI.TLSReload(r.Context())
+	h.audit(r, "tls.reload", nil, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
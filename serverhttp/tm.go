@@ -0,0 +1,112 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
+)
+
+func (h httpHandler) tmStats(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.TMStats(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+func (h httpHandler) tmHashStats(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.TMHashStats(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
+
+// tmTransactions lists transactions, optionally only those matching the
+// callid and method query params
+func (h httpHandler) tmTransactions(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.TMList(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	callid := r.FormValue("callid")
+	method := r.FormValue("method")
+	type transaction struct {
+		jsonrpcc.TMTransaction
+		TransID string `json:"trans_id"`
+	}
+	z := []transaction{}
+	for _, t := range x {
+		if callid != "" && t.CallID != callid {
+			continue
+		}
+		if method != "" && t.Method != method {
+			continue
+		}
+		z = append(z, transaction{TMTransaction: t, TransID: t.TransID()})
+	}
+	json.NewEncoder(w).Encode(z)
+}
+
+func (h httpHandler) tmCancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	type request struct {
+		CallID string `json:"callid"`
+		CSeq   string `json:"cseq"`
+	}
+	z := request{}
+	err := json.NewDecoder(r.Body).Decode(&z)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if z.CallID == "" || z.CSeq == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("callid and cseq are required")
+		return
+	}
+	start := time.Now()
+	err = h.jsonrpcAPI.TMCancel(ctx, z.CallID, z.CSeq)
+	h.audit(r, "tm.cancel", map[string]string{"callid": z.CallID, "cseq": z.CSeq}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpHandler) tmReply(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	z := jsonrpcc.TMReply{}
+	err := json.NewDecoder(r.Body).Decode(&z)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	if z.Code < 100 || z.Code > 699 || z.Reason == "" || z.TransID == "" || z.ToTag == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("code must be 100-699, reason, trans_id and to_tag are required")
+		return
+	}
+	start := time.Now()
+	err = h.jsonrpcAPI.TMReply(ctx, z)
+	h.audit(r, "tm.reply", map[string]string{"trans_id": z.TransID, "code": strconv.Itoa(z.Code), "reason": z.Reason, "to_tag": z.ToTag}, start, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
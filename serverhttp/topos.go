@@ -0,0 +1,16 @@
+package serverhttp
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (h httpHandler) toposStats(w http.ResponseWriter, r *http.Request) {
+	x, err := h.jsonrpcAPI.ToposStats(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(x)
+}
@@ -3,6 +3,8 @@ package serverhttp
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/voipxswitch/kamailio-jsonrpc-client/internal/jsonrpcc"
 )
@@ -24,6 +26,7 @@ func (h httpHandler) uacRegister(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	start := time.Now()
 	err = h.jsonrpcAPI.Register(ctx, jsonrpcc.UACAddRequest{
 		ID:           z.ID,
 		Username:     z.Username,
@@ -33,6 +36,15 @@ func (h httpHandler) uacRegister(w http.ResponseWriter, r *http.Request) {
 		AuthProxy:    z.AuthProxy,
 		RandomDelay:  z.RandomDelay,
 	})
+	h.audit(r, "uac.register", map[string]string{
+		"id":            z.ID,
+		"username":      z.Username,
+		"domain":        z.Domain,
+		"auth_username": z.AuthUsername,
+		"auth_password": z.AuthPassword,
+		"proxy":         z.AuthProxy,
+		"random_delay":  strconv.Itoa(z.RandomDelay),
+	}, start, err)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -68,7 +80,9 @@ func (h httpHandler) uacUnregister(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode("missing username or domain")
 		return
 	}
+	start := time.Now()
 	err = h.jsonrpcAPI.Unregister(ctx, id, username, domain)
+	h.audit(r, "uac.unregister", map[string]string{"id": id, "username": username, "domain": domain}, start, err)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return